@@ -7,9 +7,9 @@ import (
 )
 
 type ASPConfig struct {
-	ViewState          string
-	ViewStateGenerator string
-	EventValidation    string
+	ViewState          string `json:"view_state"`
+	ViewStateGenerator string `json:"view_state_generator"`
+	EventValidation    string `json:"event_validation"`
 }
 
 func NewASPConfig() *ASPConfig {
@@ -49,3 +49,16 @@ func (cfg *ASPConfig) Update(r io.Reader) error {
 		}
 	}
 }
+
+// other で値が見つかった項目だけを上書きする
+func (cfg *ASPConfig) merge(other *ASPConfig) {
+	if other.ViewState != "" {
+		cfg.ViewState = other.ViewState
+	}
+	if other.ViewStateGenerator != "" {
+		cfg.ViewStateGenerator = other.ViewStateGenerator
+	}
+	if other.EventValidation != "" {
+		cfg.EventValidation = other.EventValidation
+	}
+}
@@ -1,9 +1,12 @@
 package tcmrsv
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type LoginParams struct {
@@ -11,13 +14,38 @@ type LoginParams struct {
 	Password string
 }
 
+// 再ログインに使う認証情報を返す
+type CredentialProvider func(ctx context.Context) (*LoginParams, error)
+
 func (c *Client) Login(params *LoginParams) error {
-	req, err := http.NewRequest(http.MethodGet, c.baseURL+ENDPOINT_LOGIN, nil)
+	return c.LoginContext(context.Background(), params)
+}
+
+func (c *Client) LoginContext(ctx context.Context, params *LoginParams) error {
+	err := c.withRetry(ctx, func(ctx context.Context) error {
+		return c.login(ctx, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.autoRelogin {
+		remembered := *params
+		c.mu.Lock()
+		c.lastLogin = &remembered
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (c *Client) login(ctx context.Context, params *LoginParams) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+ENDPOINT_LOGIN, nil)
 	if err != nil {
 		return err
 	}
 
-	res, err := c.DoRequest(req, false)
+	res, aspConfig, err := c.doRequest(req, false)
 	if err != nil {
 		return err
 	}
@@ -26,14 +54,14 @@ func (c *Client) Login(params *LoginParams) error {
 	form := url.Values{}
 	form.Set("__EVENTTARGET", "")
 	form.Set("__EVENTARGUMENT", "")
-	form.Set("__VIEWSTATE", c.aspConfig.ViewState)
-	form.Set("__VIEWSTATEGENERATOR", c.aspConfig.ViewStateGenerator)
-	form.Set("__EVENTVALIDATION", c.aspConfig.EventValidation)
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
 	form.Set("input_id", params.UserID)
 	form.Set("input_pass", params.Password)
 	form.Set("btnLogin", "")
 
-	req, err = http.NewRequest(http.MethodPost, c.baseURL+ENDPOINT_LOGIN, strings.NewReader(form.Encode()))
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+ENDPOINT_LOGIN, strings.NewReader(form.Encode()))
 	if err != nil {
 		return err
 	}
@@ -45,5 +73,58 @@ func (c *Client) Login(params *LoginParams) error {
 	}
 	defer res.Body.Close()
 
+	c.mu.Lock()
+	c.loggedInAt = time.Now()
+	c.mu.Unlock()
+
 	return nil
 }
+
+// fn がセッション切れで失敗した場合に、再ログインしてから一度だけやり直す
+func (c *Client) withRelogin(fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		c.mu.Lock()
+		before := c.loggedInAt
+		c.mu.Unlock()
+
+		err := fn(ctx)
+		if err == nil || !errors.Is(err, ErrAuthenticationFailed) || !c.autoRelogin {
+			return err
+		}
+
+		if err := c.relogin(ctx, before); err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	}
+}
+
+// 同時にセッション切れを検知した goroutine が一斉にログインしないよう、
+// before 以降に他の goroutine が再ログインしていればそれを使う
+func (c *Client) relogin(ctx context.Context, before time.Time) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	c.mu.Lock()
+	params := c.lastLogin
+	reloggedIn := c.loggedInAt.After(before)
+	c.mu.Unlock()
+
+	if reloggedIn {
+		return nil
+	}
+
+	if c.credentials != nil {
+		var err error
+		params, err = c.credentials(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if params == nil {
+		return ErrAuthenticationFailed
+	}
+
+	return c.login(ctx, params)
+}
@@ -1,7 +1,11 @@
 package tcmrsv
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
 	"testing"
 )
 
@@ -82,4 +86,140 @@ func TestLogin(t *testing.T) {
 			t.Errorf("Expected internal server error, got: %v", err)
 		}
 	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// キャンセル済みのコンテキストではリクエストが送信されない
+			t.Errorf("Handler called despite canceled context")
+		}))
+		defer mockServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := mockServer.Client.LoginContext(ctx, &LoginParams{
+			UserID:   "test_user",
+			Password: "test_password",
+		})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context canceled error, got: %v", err)
+		}
+	})
+}
+
+func TestAutoRelogin(t *testing.T) {
+	newRoutes := func(loggedIn *bool, logins *int) map[string]http.HandlerFunc {
+		return map[string]http.HandlerFunc{
+			"GET /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("index.html")))
+			},
+			"POST /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				*logins++
+				*loggedIn = true
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				if !*loggedIn {
+					// セッション切れの場合はログインページが返される
+					w.Write([]byte(LoadFixture("index.html")))
+					return
+				}
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+		}
+	}
+
+	t.Run("RememberedCredentials", func(t *testing.T) {
+		loggedIn, logins := false, 0
+		mockServer := NewMockServer(CreateHandler(newRoutes(&loggedIn, &logins)), WithAutoRelogin())
+		defer mockServer.Close()
+
+		if err := mockServer.Client.Login(&LoginParams{UserID: "test_user", Password: "test_password"}); err != nil {
+			t.Fatalf("Expected successful login, got error: %v", err)
+		}
+
+		// セッション切れを再現
+		loggedIn = false
+
+		reservations, err := mockServer.Client.GetMyReservations()
+		if err != nil {
+			t.Errorf("Expected successful retrieval after relogin, got error: %v", err)
+		}
+		if len(reservations) != 2 {
+			t.Errorf("Expected 2 reservations, got %d", len(reservations))
+		}
+		if logins != 2 {
+			t.Errorf("Expected 2 logins, got %d", logins)
+		}
+	})
+
+	t.Run("LatestCredentials", func(t *testing.T) {
+		loggedIn, logins := false, 0
+		mockServer := NewMockServer(CreateHandler(newRoutes(&loggedIn, &logins)), WithAutoRelogin())
+		defer mockServer.Close()
+
+		for _, userID := range []string{"first_user", "second_user"} {
+			if err := mockServer.Client.Login(&LoginParams{UserID: userID, Password: "test_password"}); err != nil {
+				t.Fatalf("Expected successful login, got error: %v", err)
+			}
+		}
+
+		loggedIn = false
+
+		if _, err := mockServer.Client.GetMyReservations(); err != nil {
+			t.Fatalf("Expected successful retrieval after relogin, got error: %v", err)
+		}
+
+		// 再ログインには最後に成功した Login の認証情報が使われる
+		var userID string
+		for _, req := range mockServer.Requests {
+			if req.Method == http.MethodPost && req.URL.Path == "/index.aspx" {
+				body, _ := io.ReadAll(req.Body)
+				form, _ := url.ParseQuery(string(body))
+				userID = form.Get("input_id")
+			}
+		}
+		if userID != "second_user" {
+			t.Errorf("Expected relogin as second_user, got %q", userID)
+		}
+	})
+
+	t.Run("CredentialProvider", func(t *testing.T) {
+		loggedIn, logins := false, 0
+		calls := 0
+		mockServer := NewMockServer(CreateHandler(newRoutes(&loggedIn, &logins)), WithCredentialProvider(func(ctx context.Context) (*LoginParams, error) {
+			calls++
+			return &LoginParams{UserID: "test_user", Password: "test_password"}, nil
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetMyReservations()
+		if err != nil {
+			t.Errorf("Expected successful retrieval after relogin, got error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected credential provider to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		loggedIn, logins := false, 0
+		mockServer := NewMockServer(CreateHandler(newRoutes(&loggedIn, &logins)))
+		defer mockServer.Close()
+
+		if err := mockServer.Client.Login(&LoginParams{UserID: "test_user", Password: "test_password"}); err != nil {
+			t.Fatalf("Expected successful login, got error: %v", err)
+		}
+
+		loggedIn = false
+
+		_, err := mockServer.Client.GetMyReservations()
+		if err != ErrAuthenticationFailed {
+			t.Errorf("Expected authentication failure, got: %v", err)
+		}
+		if logins != 1 {
+			t.Errorf("Expected 1 login, got %d", logins)
+		}
+	})
 }
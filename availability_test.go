@@ -1,8 +1,11 @@
 package tcmrsv
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetRoomAvailability(t *testing.T) {
@@ -205,4 +208,32 @@ func TestGetRoomAvailability(t *testing.T) {
 			t.Errorf("Expected internal server error, got: %v", err)
 		}
 	})
+
+	t.Run("ContextTimeout", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				// サーバーが混雑して応答が遅い状態を再現
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := mockServer.Client.GetRoomAvailabilityContext(ctx, &GetRoomAvailabilityParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+		})
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded error, got: %v", err)
+		}
+	})
 }
@@ -1,6 +1,7 @@
 package tcmrsv
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -75,9 +76,18 @@ func TestCancelReservation(t *testing.T) {
 			Comment:       "テストのためキャンセル",
 		})
 
-		if err != ErrCancelReservationFailed {
+		if !errors.Is(err, ErrCancelReservationFailed) {
 			t.Errorf("Expected cancellation failure, got: %v", err)
 		}
+
+		if !errors.Is(err, ErrInvalidComment) {
+			t.Errorf("Expected invalid comment error, got: %v", err)
+		}
+
+		var serverErr *ServerError
+		if !errors.As(err, &serverErr) || serverErr.Message != "※キャンセル理由が入力されていません。" {
+			t.Errorf("Expected server message to be ※キャンセル理由が入力されていません。, got: %v", err)
+		}
 	})
 
 	t.Run("AuthenticationFailure", func(t *testing.T) {
@@ -0,0 +1,94 @@
+package tcmrsv
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// 練習室一覧のデータ形式のバージョン
+const RoomCatalogVersion = 1
+
+var ErrUnsupportedCatalogVersion = errors.New("unsupported room catalog version error")
+
+//go:embed data/rooms.json
+var embeddedRoomCatalog []byte
+
+// 練習室一覧
+type RoomCatalog struct {
+	Version int    `json:"version"`
+	Rooms   []Room `json:"rooms"`
+}
+
+var defaultRoomCatalog = sync.OnceValue(func() *RoomCatalog {
+	catalog, err := ParseRoomCatalog(embeddedRoomCatalog)
+	if err != nil {
+		panic(fmt.Sprintf("tcmrsv: invalid embedded room catalog: %v", err))
+	}
+	return catalog
+})
+
+// パッケージに埋め込まれた練習室一覧を返す
+func DefaultRoomCatalog() *RoomCatalog {
+	return defaultRoomCatalog().Clone()
+}
+
+func ParseRoomCatalog(data []byte) (*RoomCatalog, error) {
+	var catalog RoomCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, err
+	}
+	if catalog.Version != RoomCatalogVersion {
+		return nil, fmt.Errorf("version %d: %w", catalog.Version, ErrUnsupportedCatalogVersion)
+	}
+	return &catalog, nil
+}
+
+// JSON ファイルから練習室一覧を読み込む
+func LoadRoomCatalog(path string) (*RoomCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoomCatalog(data)
+}
+
+// 練習室一覧を JSON ファイルに書き出す
+func (c *RoomCatalog) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	return writeFileAtomic(path, data)
+}
+
+func (c *RoomCatalog) Clone() *RoomCatalog {
+	rooms := make([]Room, len(c.Rooms))
+	copy(rooms, c.Rooms)
+	return &RoomCatalog{Version: c.Version, Rooms: rooms}
+}
+
+func (c *RoomCatalog) FindByID(id string) (Room, bool) {
+	for _, r := range c.Rooms {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Room{}, false
+}
+
+// 部屋名は NormalizeRoomName で正規化して比較する
+func (c *RoomCatalog) FindByName(name string) (Room, bool) {
+	normalized := NormalizeRoomName(name)
+	for _, r := range c.Rooms {
+		if NormalizeRoomName(r.Name) == normalized {
+			return r, true
+		}
+	}
+	return Room{}, false
+}
@@ -0,0 +1,96 @@
+package tcmrsv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRoomCatalog(t *testing.T) {
+	t.Run("DefaultCatalog", func(t *testing.T) {
+		catalog := DefaultRoomCatalog()
+
+		if catalog.Version != RoomCatalogVersion {
+			t.Errorf("Expected version %d, got %d", RoomCatalogVersion, catalog.Version)
+		}
+		if len(catalog.Rooms) == 0 {
+			t.Fatal("Expected embedded catalog to contain rooms")
+		}
+
+		room, ok := catalog.FindByID("b9f2e624-2f48-ec11-8c60-002248696fd6")
+		if !ok || room.Name != "A414（G）" || room.Campus != CampusIkebukuro {
+			t.Errorf("Expected A414（G） to be found by ID, got %+v", room)
+		}
+
+		room, ok = catalog.FindByName("楽屋2（U）旧楽屋201")
+		if !ok || room.ID != "9ed14a61-a3e2-ef11-be20-7c1e52246dd3" {
+			t.Errorf("Expected 楽屋2（U）旧楽屋201 to be found by name, got %+v", room)
+		}
+
+		if _, ok := catalog.FindByID("00000000-0000-0000-0000-000000000000"); ok {
+			t.Error("Expected unknown ID not to be found")
+		}
+
+		// 返された一覧を書き換えても埋め込みの一覧には影響しない
+		catalog.Rooms[0].Name = "changed"
+		if DefaultRoomCatalog().Rooms[0].Name == "changed" {
+			t.Error("Expected default catalog to be copied")
+		}
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rooms.json")
+
+		catalog := &RoomCatalog{
+			Version: RoomCatalogVersion,
+			Rooms: []Room{
+				{ID: "9ed14a61-a3e2-ef11-be20-7c1e52246dd3", Name: "楽屋2（U）旧楽屋201", PianoType: RoomPianoTypeUpright, PianoNumber: 1, Campus: CampusNakameguro, Floor: 2},
+			},
+		}
+		if err := catalog.Save(path); err != nil {
+			t.Fatalf("Expected catalog to be saved, got error: %v", err)
+		}
+
+		loaded, err := LoadRoomCatalog(path)
+		if err != nil {
+			t.Fatalf("Expected catalog to be loaded, got error: %v", err)
+		}
+		if len(loaded.Rooms) != 1 || loaded.Rooms[0] != catalog.Rooms[0] {
+			t.Errorf("Expected %+v, got %+v", catalog.Rooms, loaded.Rooms)
+		}
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rooms.json")
+		if err := os.WriteFile(path, []byte(`{"version": 999, "rooms": []}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadRoomCatalog(path)
+		if !errors.Is(err, ErrUnsupportedCatalogVersion) {
+			t.Errorf("Expected unsupported catalog version error, got: %v", err)
+		}
+	})
+
+	t.Run("WithRoomCatalog", func(t *testing.T) {
+		catalog := &RoomCatalog{
+			Version: RoomCatalogVersion,
+			Rooms: []Room{
+				{ID: "5df2e624-2f48-ec11-8c60-002248696fd6", Name: "P 227（G）", Campus: CampusNakameguro},
+			},
+		}
+
+		client := New(WithRoomCatalog(catalog))
+		rooms := client.GetRooms()
+		if len(rooms) != 1 || rooms[0].Name != "P 227（G）" {
+			t.Errorf("Expected rooms from the supplied catalog, got %+v", rooms)
+		}
+
+		// 指定した一覧を後から書き換えてもクライアントには影響しない
+		catalog.Rooms[0].Name = "changed"
+		if client.GetRooms()[0].Name != "P 227（G）" {
+			t.Error("Expected catalog to be copied")
+		}
+	})
+}
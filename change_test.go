@@ -0,0 +1,185 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChangeReservation(t *testing.T) {
+	// 予約一覧の最初の予約（A414（G） 17:00-22:30）
+	const reservationID = "fa791156-cc27-f011-8c4e-000d3ace9c3e"
+
+	tomorrow := Today().AddDays(1)
+
+	// change.html は実サイトから取得したページではないため、フォームの項目は推測である。
+	// 変更できたかどうかは予約一覧で確認するため、POST の応答には変更ページをそのまま返す
+	newRoutes := func(list string, changed *atomic.Bool) map[string]http.HandlerFunc {
+		return map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				if changed != nil && changed.Load() {
+					w.Write([]byte(strings.Replace(list, "17:00-22:30", "17:00-19:00", 1)))
+					return
+				}
+				w.Write([]byte(list))
+			},
+			"GET /personal/facility/change.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/change.html")))
+			},
+			"POST /personal/facility/change.aspx": func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("Failed to parse form: %v", err)
+				}
+				if r.PostForm.Get("fromh") == "17" && r.PostForm.Get("fromm") == "00" && r.PostForm.Get("toh") == "19" && r.PostForm.Get("tom") == "00" {
+					if changed != nil {
+						changed.Store(true)
+					}
+				}
+				w.Write([]byte(LoadFixture("personal/facility/change.html")))
+			},
+		}
+	}
+
+	params := func(id string) *changeReservationParams {
+		return &changeReservationParams{
+			ReservationID: id,
+			FromHour:      17,
+			FromMinute:    0,
+			ToHour:        19,
+			ToMinute:      0,
+		}
+	}
+
+	countRequests := func(ms *MockServer, method, path string) int {
+		n := 0
+		for _, req := range ms.Requests {
+			if req.Method == method && req.URL.Path == path {
+				n++
+			}
+		}
+		return n
+	}
+
+	t.Run("SuccessfulChange", func(t *testing.T) {
+		var changed atomic.Bool
+		mockServer := NewMockServer(CreateHandler(newRoutes(reservationListFor(tomorrow), &changed)))
+		defer mockServer.Close()
+
+		if err := mockServer.Client.changeReservation(context.Background(), params(reservationID)); err != nil {
+			t.Fatalf("Expected successful change, got error: %v", err)
+		}
+
+		// 変更前と変更後に予約一覧を確認する
+		if n := countRequests(mockServer, http.MethodGet, "/personal/facility/index.aspx"); n != 2 {
+			t.Errorf("Expected reservation list to be fetched twice, got %d", n)
+		}
+		if n := countRequests(mockServer, http.MethodPost, "/personal/facility/change.aspx"); n != 1 {
+			t.Errorf("Expected change to be submitted once, got %d", n)
+		}
+	})
+
+	t.Run("AlreadyChanged", func(t *testing.T) {
+		list := strings.Replace(reservationListFor(tomorrow), "17:00-22:30", "17:00-19:00", 1)
+		mockServer := NewMockServer(CreateHandler(newRoutes(list, nil)))
+		defer mockServer.Close()
+
+		if err := mockServer.Client.changeReservation(context.Background(), params(reservationID)); err != nil {
+			t.Fatalf("Expected successful change, got error: %v", err)
+		}
+		if n := countRequests(mockServer, http.MethodPost, "/personal/facility/change.aspx"); n != 0 {
+			t.Errorf("Expected no change to be submitted, got %d", n)
+		}
+	})
+
+	t.Run("ChangeFailure", func(t *testing.T) {
+		// 変更を送信しても予約一覧の利用時間が変わらない
+		mockServer := NewMockServer(CreateHandler(newRoutes(reservationListFor(tomorrow), nil)))
+		defer mockServer.Close()
+
+		err := mockServer.Client.changeReservation(context.Background(), params(reservationID))
+		if !errors.Is(err, ErrChangeReservationFailed) {
+			t.Errorf("Expected change failure, got: %v", err)
+		}
+	})
+
+	t.Run("UnexpectedForm", func(t *testing.T) {
+		routes := newRoutes(reservationListFor(tomorrow), nil)
+		routes["GET /personal/facility/change.aspx"] = func(w http.ResponseWriter, r *http.Request) {
+			// 時間の select がないページ
+			w.Write([]byte(LoadFixture("personal/facility/cancel.html")))
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		err := mockServer.Client.changeReservation(context.Background(), params(reservationID))
+		if !errors.Is(err, ErrUnexpectedPage) {
+			t.Errorf("Expected unexpected page error, got: %v", err)
+		}
+		if n := countRequests(mockServer, http.MethodPost, "/personal/facility/change.aspx"); n != 0 {
+			t.Errorf("Expected nothing to be submitted, got %d", n)
+		}
+	})
+
+	t.Run("ReservationNotFound", func(t *testing.T) {
+		mockServer := NewMockServer(CreateHandler(newRoutes(reservationListFor(tomorrow), nil)))
+		defer mockServer.Close()
+
+		err := mockServer.Client.changeReservation(context.Background(), params("59253854-3628-f011-8c4e-000d3a51476f"))
+		if err != ErrReservationNotFound {
+			t.Errorf("Expected reservation not found error, got: %v", err)
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// このハンドラーは入力検証エラーのため呼び出されるべきではない
+			t.Errorf("Handler called despite validation errors")
+		}))
+		defer mockServer.Close()
+
+		// 無効な予約IDのテスト
+		if err := mockServer.Client.changeReservation(context.Background(), params("invalid-id")); err != ErrInvalidIDFormat {
+			t.Errorf("Expected invalid ID format error, got: %v", err)
+		}
+
+		// 無効な時間範囲のテスト
+		invalid := params(reservationID)
+		invalid.ToHour = 12 // 終了時間が開始時間より前
+		if err := mockServer.Client.changeReservation(context.Background(), invalid); err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error, got: %v", err)
+		}
+	})
+
+	t.Run("DateOutOfRange", func(t *testing.T) {
+		// 予約日は予約一覧から取得する
+		mockServer := NewMockServer(CreateHandler(newRoutes(reservationListFor(Today().AddDays(4)), nil)))
+		defer mockServer.Close()
+
+		if err := mockServer.Client.changeReservation(context.Background(), params(reservationID)); err != ErrDateOutOfRange {
+			t.Errorf("Expected date out of range error, got: %v", err)
+		}
+		if n := countRequests(mockServer, http.MethodGet, "/personal/facility/change.aspx"); n != 0 {
+			t.Errorf("Expected change page not to be opened, got %d", n)
+		}
+	})
+
+	t.Run("AuthenticationFailure", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				// ログインページにリダイレクト
+				w.Write([]byte(LoadFixture("index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		if err := mockServer.Client.changeReservation(context.Background(), params(reservationID)); err != ErrAuthenticationFailed {
+			t.Errorf("Expected authentication failure, got: %v", err)
+		}
+	})
+}
@@ -5,17 +5,41 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"sync"
+	"time"
 )
 
+// Client は複数の goroutine から同時に使用できる
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient    *http.Client
+	baseURL       string
+	retryPolicy   RetryPolicy
+	autoRelogin   bool
+	credentials   CredentialProvider
+	rooms         *RoomCatalog
+	roomIndex     *roomIndex
+	clock         Clock
+	bookingPolicy BookingPolicy
+
+	// 以下は mu で保護する
+	mu         sync.Mutex
 	aspConfig  *ASPConfig
+	lastLogin  *LoginParams
+	loggedInAt time.Time
+
+	// 再ログインを同時に 1 つだけ実行するためのロック
+	loginMu sync.Mutex
 }
 
 type ClientConfig struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient    *http.Client
+	baseURL       string
+	retryPolicy   RetryPolicy
+	autoRelogin   bool
+	credentials   CredentialProvider
+	rooms         *RoomCatalog
+	clock         Clock
+	bookingPolicy BookingPolicy
 }
 
 func newClientConfig() *ClientConfig {
@@ -29,7 +53,10 @@ func newClientConfig() *ClientConfig {
 				return nil
 			},
 		},
-		baseURL: "https://www.tokyo-ondai-career.jp",
+		baseURL:       "https://www.tokyo-ondai-career.jp",
+		retryPolicy:   RetryPolicy{MaxAttempts: 1},
+		clock:         SystemClock,
+		bookingPolicy: DefaultBookingPolicy(),
 	}
 }
 
@@ -49,28 +76,118 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.retryPolicy = policy
+	}
+}
+
+// セッション切れを検知したときに、最後に成功した Login の認証情報で再ログインする
+func WithAutoRelogin() ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.autoRelogin = true
+	}
+}
+
+// セッション切れを検知したときに、provider から取得した認証情報で再ログインする
+func WithCredentialProvider(provider CredentialProvider) ClientOption {
+	return func(cfg *ClientConfig) {
+		if provider != nil {
+			cfg.autoRelogin = true
+			cfg.credentials = provider
+		}
+	}
+}
+
+// 埋め込みの練習室一覧の代わりに catalog を使う
+func WithRoomCatalog(catalog *RoomCatalog) ClientOption {
+	return func(cfg *ClientConfig) {
+		if catalog != nil {
+			cfg.rooms = catalog.Clone()
+		}
+	}
+}
+
+// 予約可能な日付や時間の判定に使う現在時刻の取得元を指定する
+func WithClock(clock Clock) ClientOption {
+	return func(cfg *ClientConfig) {
+		if clock != nil {
+			cfg.clock = clock
+		}
+	}
+}
+
+// 予約を受け付ける日付の規則を指定する
+func WithBookingPolicy(policy BookingPolicy) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.bookingPolicy = policy
+	}
+}
+
 func New(options ...ClientOption) *Client {
 	cfg := newClientConfig()
 	for _, opt := range options {
 		opt(cfg)
 	}
 
+	var index *roomIndex
+	if cfg.rooms != nil {
+		index = newRoomIndex(cfg.rooms.Rooms)
+	}
+
 	return &Client{
-		httpClient: cfg.httpClient,
-		baseURL:    cfg.baseURL,
-		aspConfig:  NewASPConfig(),
+		httpClient:    cfg.httpClient,
+		baseURL:       cfg.baseURL,
+		aspConfig:     NewASPConfig(),
+		retryPolicy:   cfg.retryPolicy,
+		autoRelogin:   cfg.autoRelogin,
+		credentials:   cfg.credentials,
+		rooms:         cfg.rooms,
+		roomIndex:     index,
+		clock:         cfg.clock,
+		bookingPolicy: cfg.bookingPolicy,
 	}
 }
 
+// 日本時間の現在時刻
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now().In(jst)
+	}
+	return c.clock.Now().In(jst)
+}
+
+// クライアントの Clock と BookingPolicy で、現在予約できる日付の範囲を返す
+func (c *Client) BookingWindow() (earliest, latest Date) {
+	return c.bookingPolicy.BookingWindow(c.now())
+}
+
+// クライアントの Clock と BookingPolicy で、次に新しい日付が予約できるようになる時刻を返す
+func (c *Client) NextReleaseTime() time.Time {
+	return c.bookingPolicy.NextReleaseTime(c.now())
+}
+
+// クライアントの Clock から見た今日の日付
+func (c *Client) Today() Date {
+	return FromTime(c.now())
+}
+
 func (c *Client) DoRequest(req *http.Request, requireAuth bool) (*http.Response, error) {
+	res, _, err := c.doRequest(req, requireAuth)
+	return res, err
+}
+
+// doRequest はレスポンスに含まれる ASP.NET のトークンも返す。
+// フォームを送信する操作はこのトークンを使うことで、他の goroutine の操作とトークンを取り違えない
+func (c *Client) doRequest(req *http.Request, requireAuth bool) (*http.Response, *ASPConfig, error) {
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	bodyBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	res.Body.Close()
 
@@ -80,27 +197,32 @@ func (c *Client) DoRequest(req *http.Request, requireAuth bool) (*http.Response,
 
 	isErr, err := isInternalServerErrorPage(reader())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if isErr {
-		return nil, ErrInternalServer
+		return nil, nil, ErrInternalServer
 	}
 
 	if requireAuth {
 		isAuthErr, err := isLoginPage(reader())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if isAuthErr {
-			return nil, ErrAuthenticationFailed
+			return nil, nil, ErrAuthenticationFailed
 		}
 	}
 
-	if err := c.aspConfig.Update(reader()); err != nil {
-		return nil, err
+	aspConfig := NewASPConfig()
+	if err := aspConfig.Update(reader()); err != nil {
+		return nil, nil, err
 	}
 
+	c.mu.Lock()
+	c.aspConfig.merge(aspConfig)
+	c.mu.Unlock()
+
 	res.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
-	return res, nil
+	return res, aspConfig, nil
 }
@@ -2,20 +2,25 @@ package tcmrsv
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
 )
 
 type MockServer struct {
 	Server   *httptest.Server
 	Client   *Client
 	Requests []*http.Request // リクエスト検証用
+	mu       sync.Mutex
 }
 
-func NewMockServer(handler http.HandlerFunc) *MockServer {
+func NewMockServer(handler http.HandlerFunc, options ...ClientOption) *MockServer {
 	ms := &MockServer{
 		Requests: make([]*http.Request, 0),
 	}
@@ -34,17 +39,19 @@ func NewMockServer(handler http.HandlerFunc) *MockServer {
 			r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		}
 
+		ms.mu.Lock()
 		ms.Requests = append(ms.Requests, reqCopy)
+		ms.mu.Unlock()
 
 		handler(w, r)
 	})
 
 	ms.Server = httptest.NewServer(recordingHandler)
 
-	ms.Client = New(
+	ms.Client = New(append([]ClientOption{
 		WithBaseURL(ms.Server.URL),
 		WithHTTPClient(ms.Server.Client()),
-	)
+	}, options...)...)
 
 	return ms
 }
@@ -79,3 +86,62 @@ func CreateHandler(routes map[string]http.HandlerFunc) http.HandlerFunc {
 		w.Write([]byte("Not Found"))
 	}
 }
+
+func TestClientConcurrentReserve(t *testing.T) {
+	const workers = 16
+
+	// 部屋ごとに異なる __EVENTVALIDATION を返し、POST で同じ値が送られてきたか検証する
+	confirms := LoadFixture("personal/facility/confirms.html")
+	eventValidation := regexp.MustCompile(`id="__EVENTVALIDATION" value="[^"]*"`)
+
+	routes := map[string]http.HandlerFunc{
+		"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+			room := r.URL.Query().Get("room")
+			w.Write([]byte(eventValidation.ReplaceAllString(confirms, `id="__EVENTVALIDATION" value="`+room+`"`)))
+		},
+		"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("__EVENTVALIDATION") != r.URL.Query().Get("room") {
+				w.Write([]byte(LoadFixture("personal/facility/confirms_failure.html")))
+				return
+			}
+			w.Write([]byte(LoadFixture("personal/facility/done.html")))
+		},
+	}
+
+	mockServer := NewMockServer(CreateHandler(routes))
+	defer mockServer.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reservation, err := mockServer.Client.Reserve(&ReserveParams{
+				Campus:     CampusNakameguro,
+				RoomID:     fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+				Date:       Today().AddDays(1),
+				FromHour:   21,
+				FromMinute: 0,
+				ToHour:     22,
+				ToMinute:   0,
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			// 予約一覧は用意していないため、作成した予約の特定には失敗する
+			if !reservation.Unresolved {
+				errs <- fmt.Errorf("expected unresolved reservation, got %+v", reservation)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected concurrent reservation to succeed, got error: %v", err)
+		}
+	}
+}
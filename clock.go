@@ -0,0 +1,49 @@
+package tcmrsv
+
+import (
+	"sync"
+	"time"
+)
+
+// 現在時刻の取得元。予約可能な日付や時間の判定に使う
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// 実際の時刻を返す Clock
+var SystemClock Clock = systemClock{}
+
+// 任意の時刻を返す Clock
+// テストや「12:00 になったら何が予約できるか」の確認に使う
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,113 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, time.May, 4, 11, 59, 0, 0, jst)
+	clock := NewFakeClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, clock.Now())
+	}
+
+	clock.Advance(time.Minute)
+	if !clock.Now().Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected %v, got %v", start.Add(time.Minute), clock.Now())
+	}
+
+	next := time.Date(2025, time.May, 5, 0, 0, 0, 0, jst)
+	clock.Set(next)
+	if !clock.Now().Equal(next) {
+		t.Errorf("Expected %v, got %v", next, clock.Now())
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Run("NoonRelease", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+		}
+
+		clock := NewFakeClock(time.Date(2025, time.May, 4, 11, 59, 0, 0, jst))
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(clock))
+		defer mockServer.Close()
+
+		if !mockServer.Client.Today().Equals(NewDate(2025, time.May, 4)) {
+			t.Errorf("Expected today to be 2025-05-04, got %v", mockServer.Client.Today())
+		}
+
+		params := &GetRoomScheduleParams{
+			Campus: CampusNakameguro,
+			Date:   NewDate(2025, time.May, 6),
+		}
+
+		// 12:00 より前は 2 日後の枠は取得できない
+		if _, err := mockServer.Client.GetRoomSchedule(params); err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error before noon, got: %v", err)
+		}
+		if len(mockServer.Requests) != 0 {
+			t.Errorf("Expected no requests before noon, got %d", len(mockServer.Requests))
+		}
+
+		clock.Advance(time.Minute)
+
+		if _, err := mockServer.Client.GetRoomSchedule(params); err != nil {
+			t.Errorf("Expected successful schedule retrieval at noon, got error: %v", err)
+		}
+	})
+
+	t.Run("PastSlotsToday", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+		}
+
+		clock := NewFakeClock(time.Date(2025, time.May, 4, 12, 10, 0, 0, jst))
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(clock))
+		defer mockServer.Close()
+
+		availabilities, err := mockServer.Client.GetRoomAvailability(&GetRoomAvailabilityParams{
+			Campus: CampusNakameguro,
+			Date:   NewDate(2025, time.May, 4),
+		})
+		if err != nil {
+			t.Fatalf("Expected successful availability retrieval, got error: %v", err)
+		}
+
+		// 過ぎた枠は空きに含まれない
+		for _, a := range availabilities {
+			for _, at := range a.AvailableTimes {
+				if at.Hour*60+at.Minute < 12*60+10 {
+					t.Errorf("Expected %02d:%02d in %s to be excluded", at.Hour, at.Minute, a.Room.Name)
+				}
+			}
+		}
+	})
+
+	t.Run("ReserveValidation", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Handler called despite validation errors")
+		}), WithClock(NewFakeClock(time.Date(2025, time.May, 4, 15, 0, 0, 0, jst))))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.Reserve(&ReserveParams{
+			Campus:     CampusIkebukuro,
+			RoomID:     "b9f2e624-2f48-ec11-8c60-002248696fd6",
+			Date:       NewDate(2025, time.May, 4),
+			FromHour:   14,
+			FromMinute: 30,
+			ToHour:     16,
+			ToMinute:   0,
+		})
+		if err != ErrTimeInPast {
+			t.Errorf("Expected time in past error, got: %v", err)
+		}
+	})
+}
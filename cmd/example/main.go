@@ -38,7 +38,7 @@ func main() {
 
 	fmt.Println("Login successful!")
 
-	if err = client.Reserve(&tcmrsv.ReserveParams{
+	reservation, err := client.Reserve(&tcmrsv.ReserveParams{
 		Campus:     tcmrsv.CampusNakameguro,
 		RoomID:     "2df2e624-2f48-ec11-8c60-002248696fd6",
 		Date:       tcmrsv.Today().AddDays(2),
@@ -46,11 +46,17 @@ func main() {
 		FromMinute: 0,
 		ToHour:     14,
 		ToMinute:   0,
-	}); err != nil {
+	})
+	if err != nil {
 		panic(err)
 	}
 
-	fmt.Println("Reservation successful!")
+	if reservation.Unresolved {
+		// 予約は完了しているが、予約一覧から特定できなかった
+		fmt.Println("Reservation successful! (ID unknown)")
+	} else {
+		fmt.Println("Reservation successful!", reservation.ID)
+	}
 
 	reservations, err := client.GetMyReservations()
 	if err != nil {
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/ekkx/tcmrsv"
+)
+
+type Config struct {
+	UserID       string `env:"USER_ID"`
+	UserPassword string `env:"USER_PW"`
+}
+
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// 予約ページから取得した練習室と練習室一覧を比較し、更新した一覧を書き出す
+//
+//	USER_ID=... USER_PW=... go run ./cmd/roomsync -out data/rooms.json
+func main() {
+	base := flag.String("catalog", "", "比較元の練習室一覧（省略時は埋め込みの一覧）")
+	out := flag.String("out", "data/rooms.json", "更新した練習室一覧の書き出し先")
+	dryRun := flag.Bool("dry-run", false, "差分を表示するだけで書き出さない")
+	flag.Parse()
+
+	cfg, err := NewConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	catalog := tcmrsv.DefaultRoomCatalog()
+	if *base != "" {
+		catalog, err = tcmrsv.LoadRoomCatalog(*base)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	client := tcmrsv.New()
+
+	if err := client.Login(&tcmrsv.LoginParams{
+		UserID:   cfg.UserID,
+		Password: cfg.UserPassword,
+	}); err != nil {
+		panic(err)
+	}
+
+	// 教室は休日のみ表示されるため、予約できる日付をすべて確認する
+	var discovered []tcmrsv.Room
+	for _, campus := range []tcmrsv.Campus{tcmrsv.CampusNakameguro, tcmrsv.CampusIkebukuro} {
+		for days := 0; days <= 2; days++ {
+			rooms, err := client.DiscoverRooms(&tcmrsv.DiscoverRoomsParams{
+				Campus: campus,
+				Date:   tcmrsv.Today().AddDays(days),
+			})
+			if errors.Is(err, tcmrsv.ErrInvalidTimeRange) {
+				continue
+			}
+			if err != nil {
+				panic(err)
+			}
+			discovered = tcmrsv.MergeRooms(discovered, rooms)
+		}
+	}
+
+	diff := tcmrsv.DiffRooms(catalog.Rooms, discovered)
+	if diff.IsEmpty() {
+		fmt.Println("No changes.")
+		return
+	}
+
+	// ID の分からない部屋は予約に使えないため、一覧には追加しない
+	var added []tcmrsv.Room
+	for _, r := range diff.Added {
+		if r.ID == "" {
+			fmt.Printf("? %s (ID unknown, skipped)\n", r.Name)
+			continue
+		}
+		fmt.Printf("+ %s %s\n", r.ID, r.Name)
+		added = append(added, r)
+	}
+	for _, r := range diff.Renamed {
+		fmt.Printf("~ %s %s -> %s\n", r.ID, r.OldName, r.NewName)
+	}
+	for _, r := range diff.Removed {
+		fmt.Printf("- %s %s (not shown, kept)\n", r.ID, r.Name)
+	}
+
+	if *dryRun {
+		return
+	}
+
+	var renamed []tcmrsv.Room
+	for _, r := range diff.Renamed {
+		room, _ := catalog.FindByID(r.ID)
+		room.Name = r.NewName
+		renamed = append(renamed, room)
+	}
+
+	catalog.Rooms = tcmrsv.MergeRooms(catalog.Rooms, append(renamed, added...))
+	if err := catalog.Save(*out); err != nil {
+		panic(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %d rooms to %s\n", len(catalog.Rooms), *out)
+}
@@ -0,0 +1,301 @@
+package tcmrsv
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type DiscoverRoomsParams struct {
+	Campus Campus
+	Date   Date
+}
+
+// 予約ページに表示されている練習室を取得する
+// ID は空き枠のチェックボックスから取得するため、空きのない部屋や 〇 表示のページでは空になる
+func (c *Client) DiscoverRooms(params *DiscoverRoomsParams) ([]Room, error) {
+	return c.DiscoverRoomsContext(context.Background(), params)
+}
+
+func (c *Client) DiscoverRoomsContext(ctx context.Context, params *DiscoverRoomsParams) ([]Room, error) {
+	if !params.Campus.IsValid() {
+		return nil, ErrInvalidCampus
+	}
+	if !c.bookingPolicy.IsDateBookable(c.now(), params.Date) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	var rooms []Room
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		rooms, err = c.discoverRooms(ctx, params)
+		return err
+	}))
+	return rooms, err
+}
+
+func (c *Client) discoverRooms(ctx context.Context, params *DiscoverRoomsParams) ([]Room, error) {
+	pageURL, err := reserveURL(c.baseURL, params.Campus, params.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return parseDiscoveredRooms(res.Body, params.Campus)
+}
+
+var (
+	roomNumberRegex = regexp.MustCompile(`(\d)\d{2}`)
+	floorRegex      = regexp.MustCompile(`(\d+)\s*(?:F|階)`)
+)
+
+// 部屋名の部屋番号（例: P 227, A地下103）とフロアの見出し（例: 中目黒・代官山 2F, 池袋 教室（休日のみ））から
+// 階数・地下・教室かどうかを求める
+func applyFloor(room *Room, name, group string) {
+	room.IsClassroom = strings.Contains(group, "教室")
+	room.IsBasement = strings.Contains(name, "地下")
+
+	if m := roomNumberRegex.FindStringSubmatch(name); m != nil {
+		room.Floor, _ = strconv.Atoi(m[1])
+	} else if m := floorRegex.FindStringSubmatch(group); m != nil {
+		room.Floor, _ = strconv.Atoi(m[1])
+	}
+}
+
+var pianoNumberRegex = regexp.MustCompile(`(\d+)台`)
+
+// 部屋名の括弧書き（例: （G）, （U）, （G2台）, （無））からピアノの種類と台数を求める
+// 括弧書きがなければ行の class（g / a）で判断する
+func applyPianoType(room *Room, name, class string) {
+	room.PianoType = RoomPianoTypeUnknown
+	room.PianoNumber = 1
+
+	switch {
+	case strings.Contains(name, "（G"):
+		room.PianoType = RoomPianoTypeGrand
+	case strings.Contains(name, "（U"):
+		room.PianoType = RoomPianoTypeUpright
+	case strings.Contains(name, "（無"):
+		room.PianoType = RoomPianoTypeNone
+	case class == "g":
+		room.PianoType = RoomPianoTypeGrand
+	case class == "a":
+		room.PianoType = RoomPianoTypeUpright
+	}
+
+	if m := pianoNumberRegex.FindStringSubmatch(name); m != nil {
+		room.PianoNumber, _ = strconv.Atoi(m[1])
+	}
+}
+
+func parseDiscoveredRooms(r io.Reader, campus Campus) ([]Room, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		rooms         []Room
+		current       *Room
+		group         string
+		rowClass      string
+		insideHeading bool
+		insideTable   bool
+		insideRow     bool
+		colIndex      int
+	)
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return rooms, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+
+			switch t.Data {
+			case "h3":
+				// pnlGrp* の見出しにフロア名が表示される
+				if attrs["class"] == "reservation-room" {
+					insideHeading = true
+				}
+
+			case "table":
+				if strings.HasPrefix(attrs["id"], "aspTable") {
+					insideTable = true
+				}
+
+			case "tr":
+				if insideTable {
+					insideRow = true
+					colIndex = 0
+					current = nil
+				}
+
+			case "td":
+				if insideRow {
+					colIndex++
+					if colIndex == 1 {
+						rowClass = attrs["class"]
+					}
+				}
+
+			case "input":
+				// チェックボックスの name は <部屋ID>,<HHMM>
+				if current != nil && current.ID == "" {
+					if id, _, ok := strings.Cut(attrs["name"], ","); ok && IsIDValid(id) {
+						current.ID = id
+					}
+				}
+
+			case "span":
+				if !insideHeading && !(insideRow && colIndex == 1 && current == nil) {
+					break
+				}
+				if z.Next() != html.TextToken {
+					break
+				}
+				text := strings.TrimSpace(z.Token().Data)
+				if text == "" {
+					break
+				}
+
+				if insideHeading {
+					group = text
+				} else {
+					room := Room{Name: text, Campus: campus}
+					applyPianoType(&room, text, rowClass)
+					applyFloor(&room, text, group)
+					rooms = append(rooms, room)
+					current = &rooms[len(rooms)-1]
+				}
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "h3":
+				insideHeading = false
+			case "tr":
+				insideRow = false
+				current = nil
+			case "table":
+				insideTable = false
+			}
+		}
+	}
+}
+
+// 部屋名の変更
+type RoomRename struct {
+	ID      string
+	OldName string
+	NewName string
+}
+
+// 練習室一覧と予約ページから取得した練習室の差分
+type RoomsDiff struct {
+	// 一覧にない部屋
+	Added []Room
+	// 予約ページに表示されなかった部屋（取得したキャンパスのみ）
+	Removed []Room
+	// ID が同じで名前が変わった部屋
+	Renamed []RoomRename
+}
+
+func (d *RoomsDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0
+}
+
+// discovered に対応する base の部屋の位置を返す
+// ID が分かる場合は ID で、分からない場合は部屋名（空白や全角・半角の違いは無視する）で照合する
+func matchRoom(base []Room, discovered Room) int {
+	for i, r := range base {
+		if discovered.ID != "" && r.ID == discovered.ID {
+			return i
+		}
+	}
+	for i, r := range base {
+		if NormalizeRoomName(r.Name) == NormalizeRoomName(discovered.Name) && (discovered.ID == "" || r.ID == "") {
+			return i
+		}
+	}
+	return -1
+}
+
+// 練習室一覧と予約ページから取得した練習室を比較する
+// 休日のみ表示される教室などは、取得した日付によって Removed に含まれることがある
+func DiffRooms(base, discovered []Room) RoomsDiff {
+	var diff RoomsDiff
+
+	campuses := map[Campus]bool{}
+	matched := make([]bool, len(base))
+
+	for _, d := range discovered {
+		campuses[d.Campus] = true
+
+		i := matchRoom(base, d)
+		if i < 0 {
+			diff.Added = append(diff.Added, d)
+			continue
+		}
+		matched[i] = true
+
+		// 空白や全角・半角の違いだけなら名前の変更とみなさない
+		if NormalizeRoomName(base[i].Name) != NormalizeRoomName(d.Name) {
+			diff.Renamed = append(diff.Renamed, RoomRename{
+				ID:      base[i].ID,
+				OldName: base[i].Name,
+				NewName: d.Name,
+			})
+		}
+	}
+
+	for i, r := range base {
+		if !matched[i] && campuses[r.Campus] {
+			diff.Removed = append(diff.Removed, r)
+		}
+	}
+
+	return diff
+}
+
+// 練習室一覧に予約ページから取得した練習室を反映する
+// 既存の部屋は名前と ID だけを更新し、見つからなかった部屋は削除せずに残す
+func MergeRooms(base, discovered []Room) []Room {
+	merged := make([]Room, len(base))
+	copy(merged, base)
+
+	for _, d := range discovered {
+		i := matchRoom(merged, d)
+		if i < 0 {
+			merged = append(merged, d)
+			continue
+		}
+		merged[i].Name = d.Name
+		if merged[i].ID == "" {
+			merged[i].ID = d.ID
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,128 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDiscoverRooms(t *testing.T) {
+	t.Run("SuccessfulDiscoveryWithInputs", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		rooms, err := mockServer.Client.DiscoverRooms(&DiscoverRoomsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+		})
+		if err != nil {
+			t.Fatalf("Expected successful discovery, got error: %v", err)
+		}
+
+		if len(rooms) != 77 {
+			t.Errorf("Expected 77 rooms, got %d", len(rooms))
+		}
+
+		// 一覧に登録されている部屋は、予約ページから取得した属性も一致する
+		builtin := mockServer.Client.GetRooms()
+		for _, r := range rooms {
+			i := matchRoom(builtin, r)
+			if i < 0 {
+				continue
+			}
+			b := builtin[i]
+			if r.ID != "" && r.ID != b.ID {
+				t.Errorf("Expected ID of %s to be %s, got %s", r.Name, b.ID, r.ID)
+			}
+			if r.PianoType != b.PianoType || r.PianoNumber != b.PianoNumber || r.Floor != b.Floor || r.IsBasement != b.IsBasement || r.IsClassroom != b.IsClassroom {
+				t.Errorf("Expected %+v, got %+v", b, r)
+			}
+		}
+
+		diff := DiffRooms(builtin, rooms)
+		if len(diff.Renamed) != 0 || len(diff.Removed) != 0 {
+			t.Errorf("Expected no renamed or removed rooms, got %+v", diff)
+		}
+
+		added := map[string]bool{}
+		for _, r := range diff.Added {
+			added[r.Name] = true
+		}
+		for _, name := range []string{"P 457（G）", "C300", "C302", "C303"} {
+			if !added[name] {
+				t.Errorf("Expected %s to be added", name)
+			}
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Handler called despite validation errors")
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.DiscoverRooms(&DiscoverRoomsParams{
+			Campus: Campus("invalid"),
+			Date:   Today().AddDays(1),
+		})
+		if err != ErrInvalidCampus {
+			t.Errorf("Expected invalid campus error, got: %v", err)
+		}
+	})
+}
+
+func TestDiffRooms(t *testing.T) {
+	base := []Room{
+		{ID: "9ed14a61-a3e2-ef11-be20-7c1e52246dd3", Name: "楽屋201（U）", Campus: CampusNakameguro},
+		{ID: "5df2e624-2f48-ec11-8c60-002248696fd6", Name: "P 227（G）", Campus: CampusNakameguro},
+		{ID: "b9f2e624-2f48-ec11-8c60-002248696fd6", Name: "A414（G）", Campus: CampusIkebukuro},
+	}
+	discovered := []Room{
+		{ID: "9ed14a61-a3e2-ef11-be20-7c1e52246dd3", Name: "楽屋2（U）旧楽屋201", Campus: CampusNakameguro},
+		{ID: "", Name: "P 457（G）", Campus: CampusNakameguro},
+	}
+
+	diff := DiffRooms(base, discovered)
+
+	if len(diff.Renamed) != 1 || diff.Renamed[0].OldName != "楽屋201（U）" || diff.Renamed[0].NewName != "楽屋2（U）旧楽屋201" {
+		t.Errorf("Expected the dressing room to be renamed, got %+v", diff.Renamed)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Name != "P 457（G）" {
+		t.Errorf("Expected P 457（G） to be added, got %+v", diff.Added)
+	}
+	// 取得していないキャンパスの部屋は削除扱いにしない
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "P 227（G）" {
+		t.Errorf("Expected only P 227（G） to be removed, got %+v", diff.Removed)
+	}
+
+	merged := MergeRooms(base, discovered)
+	if len(merged) != 4 {
+		t.Fatalf("Expected 4 rooms after merge, got %d", len(merged))
+	}
+	if merged[0].Name != "楽屋2（U）旧楽屋201" {
+		t.Errorf("Expected renamed room to be updated, got %s", merged[0].Name)
+	}
+	if merged[1].Name != "P 227（G）" {
+		t.Errorf("Expected missing room to be kept, got %s", merged[1].Name)
+	}
+	if base[0].Name != "楽屋201（U）" {
+		t.Error("Expected base to be left unchanged")
+	}
+	if diff := DiffRooms(merged, discovered); len(diff.Added) != 0 || len(diff.Renamed) != 0 {
+		t.Errorf("Expected merged rooms to contain discovered rooms, got %+v", diff)
+	}
+
+	// 空白や全角・半角の違いだけでは名前の変更とみなさない
+	diff = DiffRooms(base, []Room{
+		{ID: "5df2e624-2f48-ec11-8c60-002248696fd6", Name: "P227(G)", Campus: CampusNakameguro},
+		{ID: "", Name: "楽屋201(U)", Campus: CampusNakameguro},
+	})
+	if len(diff.Renamed) != 0 || len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("Expected no difference for normalized names, got %+v", diff)
+	}
+}
@@ -6,4 +6,5 @@ const (
 	ENDPOINT_CONFIRMS           = "/personal/facility/confirms.aspx"
 	ENDPOINT_RESERVE            = "/personal/facility/reserve.aspx"
 	ENDPOINT_CANCEL_RESERVATION = "/personal/facility/cancel.aspx"
+	ENDPOINT_CHANGE_RESERVATION = "/personal/facility/change.aspx"
 )
@@ -1,7 +1,9 @@
 package tcmrsv
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"strings"
 
 	"io"
@@ -13,6 +15,10 @@ var (
 	ErrAuthenticationFailed    = errors.New("authentication failed error")
 	ErrCreateReservationFailed = errors.New("create reservation failed error")
 	ErrCancelReservationFailed = errors.New("cancel reservation failed error")
+	ErrChangeReservationFailed = errors.New("change reservation failed error")
+	ErrReservationNotFound     = errors.New("reservation not found error")
+	ErrAmbiguousReservation    = errors.New("ambiguous reservation error")
+	ErrRoomNotFound            = errors.New("room not found error")
 	ErrInvalidCampus           = errors.New("invalid campus error")
 	ErrInvalidIDFormat         = errors.New("invalid ID format error")
 	ErrDateOutOfRange          = errors.New("date out of range error")
@@ -20,8 +26,138 @@ var (
 	ErrTimeInPast              = errors.New("time in past error")
 	ErrInvalidComment          = errors.New("invalid comment error")
 	ErrInternalServer          = errors.New("internal server error")
+	ErrNoAvailableCandidate    = errors.New("no available candidate error")
+	ErrUnexpectedPage          = errors.New("unexpected page error")
+	ErrSlotsOnDifferentFloors  = errors.New("slots on different floors error")
+	ErrWatcherRunning          = errors.New("watcher already running error")
+	ErrWatcherFinished         = errors.New("watcher already finished error")
+
+	// サーバーが表示したメッセージから判別できる失敗理由
+	ErrSlotTaken = errors.New("slot taken error")
 )
 
+// サーバーが表示するメッセージと失敗理由の対応表。先に一致したものが使われる。
+// 実際のページで確認できたメッセージだけを載せ、それ以外は判別しない
+var serverMessageCatalog = []struct {
+	substr string
+	err    error
+}{
+	{"指定した時間は予約できません", ErrSlotTaken},
+	{"キャンセル理由が入力されていません", ErrInvalidComment},
+}
+
+// メッセージに対応する失敗理由を返す。対応するものがなければ nil
+func ClassifyServerMessage(message string) error {
+	for _, m := range serverMessageCatalog {
+		if strings.Contains(message, m.substr) {
+			return m.err
+		}
+	}
+	return nil
+}
+
+// 操作が失敗したときにサーバーが表示した内容
+type ServerError struct {
+	// 失敗した操作（reserve, cancel, change など）
+	Op string
+	// 失敗を表示したページのパス
+	Page string
+	// 画面に表示されたメッセージ
+	Message string
+	// メッセージ部分の HTML
+	Raw string
+	// メッセージから判別した失敗理由（ErrSlotTaken など）。判別できなければ nil
+	Kind error
+	// 操作ごとの失敗（ErrCreateReservationFailed など）
+	Err error
+}
+
+func (e *ServerError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("%s: %v: %s", e.Op, e.Err, e.Message)
+}
+
+// errors.Is で Kind と Err のどちらとも比較できる
+func (e *ServerError) Unwrap() []error {
+	if e.Kind != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Err}
+}
+
+func newServerError(op, page string, body []byte, err error) *ServerError {
+	message, raw := extractServerMessage(bytes.NewReader(body))
+	return &ServerError{
+		Op:      op,
+		Page:    page,
+		Message: message,
+		Raw:     raw,
+		Kind:    ClassifyServerMessage(message),
+		Err:     err,
+	}
+}
+
+// 赤字の span または class="message" の span に表示されたメッセージを取り出す
+func extractServerMessage(body io.Reader) (message, raw string) {
+	z := html.NewTokenizer(body)
+
+	var (
+		depth  int
+		texts  []string
+		rawBuf strings.Builder
+	)
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return "", ""
+		}
+
+		if depth > 0 {
+			rawBuf.Write(z.Raw())
+		}
+
+		switch tt {
+		case html.StartTagToken:
+			t := z.Token()
+			if t.Data != "span" {
+				continue
+			}
+			if depth > 0 {
+				depth++
+				continue
+			}
+			for _, attr := range t.Attr {
+				isRed := attr.Key == "style" && strings.Contains(strings.ToUpper(strings.ReplaceAll(attr.Val, " ", "")), "COLOR:#FF0000")
+				isMessage := attr.Key == "class" && attr.Val == "message"
+				if isRed || isMessage {
+					depth = 1
+					rawBuf.Reset()
+					rawBuf.WriteString(t.String())
+					break
+				}
+			}
+
+		case html.TextToken:
+			if depth > 0 {
+				if text := strings.TrimSpace(string(z.Text())); text != "" {
+					texts = append(texts, text)
+				}
+			}
+
+		case html.EndTagToken:
+			if depth > 0 && z.Token().Data == "span" {
+				depth--
+				if depth == 0 && len(texts) > 0 {
+					return strings.Join(texts, ""), rawBuf.String()
+				}
+			}
+		}
+	}
+}
+
 func isInternalServerErrorPage(body io.Reader) (bool, error) {
 	z := html.NewTokenizer(body)
 
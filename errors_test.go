@@ -0,0 +1,50 @@
+package tcmrsv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyServerMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    error
+	}{
+		{"指定した時間は予約できません。", ErrSlotTaken},
+		{"※キャンセル理由が入力されていません。", ErrInvalidComment},
+		// 実際のページで確認できていないメッセージは判別しない
+		{"利用時間外のため予約できません。", nil},
+		{"予約数の上限に達しています。", nil},
+		{"不明なエラー", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyServerMessage(tt.message); got != tt.want {
+			t.Errorf("ClassifyServerMessage(%q) = %v; want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestServerError(t *testing.T) {
+	err := newServerError("reserve", ENDPOINT_CONFIRMS, []byte(LoadFixture("personal/facility/confirms_failure.html")), ErrCreateReservationFailed)
+
+	if !errors.Is(err, ErrCreateReservationFailed) || !errors.Is(err, ErrSlotTaken) {
+		t.Errorf("Expected error to wrap both sentinels, got: %v", err)
+	}
+
+	if err.Raw == "" {
+		t.Error("Expected raw snippet to be set")
+	}
+
+	// メッセージが表示されていない場合は操作ごとの失敗のみ
+	err = newServerError("reserve", ENDPOINT_CONFIRMS, []byte(LoadFixture("personal/facility/confirms.html")), ErrCreateReservationFailed)
+
+	if err.Message != "" || err.Kind != nil {
+		t.Errorf("Expected no message, got %q (%v)", err.Message, err.Kind)
+	}
+
+	if !errors.Is(err, ErrCreateReservationFailed) {
+		t.Errorf("Expected error to wrap ErrCreateReservationFailed, got: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type ReserveFirstAvailableResult struct {
+	// 予約できた候補の位置（どの候補も予約できなかった場合は -1）
+	Index int
+	// 予約できた候補
+	Params *ReserveParams
+	// 作成された予約（予約一覧から特定できなかった場合は Unresolved が true）
+	Reservation *Reservation
+	// 候補ごとに飛ばした理由（試していない候補は nil）
+	Errors []error
+}
+
+// 候補を先頭から順に予約し、最初に予約できたものを返す。
+// 空き状況はキャンパスと日付ごとに 1 回だけ取得し、空いていない候補は送信せずに飛ばす。
+// 送信した候補が他の人に先に予約されていた場合（ErrSlotTaken）は次の候補に進み、
+// それ以外のエラーではその時点で中断する
+func (c *Client) ReserveFirstAvailable(candidates []ReserveParams) (*ReserveFirstAvailableResult, error) {
+	return c.ReserveFirstAvailableContext(context.Background(), candidates)
+}
+
+func (c *Client) ReserveFirstAvailableContext(ctx context.Context, candidates []ReserveParams) (*ReserveFirstAvailableResult, error) {
+	type availabilityKey struct {
+		campus Campus
+		date   Date
+	}
+	availabilities := map[availabilityKey][]RoomAvailability{}
+
+	return c.reserveFirstAvailable(ctx, candidates, func(campus Campus, date Date) ([]RoomAvailability, error) {
+		key := availabilityKey{campus: campus, date: date}
+		if available, ok := availabilities[key]; ok {
+			return available, nil
+		}
+
+		available, err := c.GetRoomAvailabilityContext(ctx, &GetRoomAvailabilityParams{
+			Campus: campus,
+			Date:   date,
+		})
+		if err != nil {
+			return nil, err
+		}
+		availabilities[key] = available
+		return available, nil
+	})
+}
+
+// 候補を先頭から順に予約する。空き状況は候補のキャンパスと日付ごとに availability から得る
+func (c *Client) reserveFirstAvailable(ctx context.Context, candidates []ReserveParams, availability func(Campus, Date) ([]RoomAvailability, error)) (*ReserveFirstAvailableResult, error) {
+	result := &ReserveFirstAvailableResult{
+		Index:  -1,
+		Errors: make([]error, len(candidates)),
+	}
+
+	now := c.now()
+
+	for i := range candidates {
+		params := &candidates[i]
+
+		// 条件を満たさない候補は送信せずに飛ばす
+		if err := params.validate(now, c.bookingPolicy); err != nil {
+			result.Errors[i] = err
+			continue
+		}
+
+		// 練習室一覧にない部屋は空き状況に含まれないため、空いていないのと区別する
+		if _, ok := c.GetRoomByID(params.RoomID); !ok {
+			result.Errors[i] = fmt.Errorf("candidate %d: %w", i, ErrRoomNotFound)
+			continue
+		}
+
+		available, err := availability(params.Campus, params.Date)
+		if err != nil {
+			return result, err
+		}
+
+		if !isCandidateAvailable(available, params) {
+			result.Errors[i] = fmt.Errorf("candidate %d: %w", i, ErrSlotTaken)
+			continue
+		}
+
+		reservation, err := c.ReserveContext(ctx, params)
+		if errors.Is(err, ErrSlotTaken) {
+			result.Errors[i] = err
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.Index = i
+		result.Params = params
+		result.Reservation = reservation
+		return result, nil
+	}
+
+	return result, ErrNoAvailableCandidate
+}
+
+// 候補の部屋の予約時間がすべて空いているか
+func isCandidateAvailable(availabilities []RoomAvailability, params *ReserveParams) bool {
+	for _, a := range availabilities {
+		if a.Room.ID != params.RoomID {
+			continue
+		}
+
+		return a.CanReserve(
+			AvailableTime{Hour: params.FromHour, Minute: params.FromMinute},
+			AvailableTime{Hour: params.ToHour, Minute: params.ToMinute},
+		)
+	}
+	return false
+}
+
+// filter に一致する練習室それぞれを params の部屋にした候補を作る。
+// 部屋は params のキャンパスのものに限られ、filter の並び順で返される
+func (c *Client) ExpandReserveCandidates(filter GetRoomsFilteredParams, params ReserveParams) []ReserveParams {
+	filter.Campuses = []Campus{params.Campus}
+
+	rooms := c.GetRoomsFiltered(filter)
+	candidates := make([]ReserveParams, 0, len(rooms))
+	for _, room := range rooms {
+		candidate := params
+		candidate.RoomID = room.ID
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
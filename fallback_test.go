@@ -0,0 +1,147 @@
+package tcmrsv
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReserveFirstAvailable(t *testing.T) {
+	tomorrow := Today().AddDays(1)
+
+	candidate := func(roomID string) ReserveParams {
+		return ReserveParams{
+			Campus:     CampusIkebukuro,
+			RoomID:     roomID,
+			Date:       tomorrow,
+			FromHour:   17,
+			FromMinute: 0,
+			ToHour:     22,
+			ToMinute:   30,
+		}
+	}
+
+	const (
+		a414 = "b9f2e624-2f48-ec11-8c60-002248696fd6" // 17:00 以降は埋まっている
+		a413 = "b7f2e624-2f48-ec11-8c60-002248696fd6"
+		a429 = "d5f2e624-2f48-ec11-8c60-002248696fd6"
+	)
+
+	t.Run("FallsBackToNextCandidate", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_without_inputs.html")))
+			},
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				// A413 は空き状況の取得後に他の人に予約された
+				if r.URL.Query().Get("room") == a413 {
+					w.Write([]byte(LoadFixture("personal/facility/confirms_failure.html")))
+					return
+				}
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(strings.ReplaceAll(reservationListFor(tomorrow), "A415（G）", "A429（G）")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		result, err := mockServer.Client.ReserveFirstAvailable([]ReserveParams{
+			candidate(a414),
+			candidate(a413),
+			candidate(a429),
+		})
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if result.Index != 2 || result.Params.RoomID != a429 {
+			t.Errorf("Expected third candidate to succeed, got index %d", result.Index)
+		}
+		if result.Reservation == nil || result.Reservation.RoomName != "A429（G）" {
+			t.Errorf("Unexpected reservation: %+v", result.Reservation)
+		}
+		for i := 0; i < 2; i++ {
+			if !errors.Is(result.Errors[i], ErrSlotTaken) {
+				t.Errorf("Expected candidate %d to be skipped as taken, got: %v", i, result.Errors[i])
+			}
+		}
+		if result.Errors[2] != nil {
+			t.Errorf("Expected no error for the successful candidate, got: %v", result.Errors[2])
+		}
+
+		// 空き状況は 1 回だけ取得し、空いていない A414 は送信しない
+		counts := map[string]int{}
+		for _, req := range mockServer.Requests {
+			counts[req.Method+" "+req.URL.Path]++
+		}
+		if counts["GET /personal/facility/reserve.aspx"] != 1 {
+			t.Errorf("Expected availability to be fetched once, got %d", counts["GET /personal/facility/reserve.aspx"])
+		}
+		if counts["POST /personal/facility/confirms.aspx"] != 2 {
+			t.Errorf("Expected 2 reservation attempts, got %d", counts["POST /personal/facility/confirms.aspx"])
+		}
+	})
+
+	t.Run("NoAvailableCandidate", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_without_inputs.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		invalid := candidate(a413)
+		invalid.RoomID = "invalid"
+
+		result, err := mockServer.Client.ReserveFirstAvailable([]ReserveParams{
+			candidate(a414),
+			invalid,
+			candidate("00000000-0000-0000-0000-000000000000"),
+		})
+		if err != ErrNoAvailableCandidate {
+			t.Errorf("Expected no available candidate error, got: %v", err)
+		}
+		if result.Index != -1 || result.Reservation != nil {
+			t.Errorf("Expected no candidate to succeed, got %+v", result)
+		}
+		if result.Errors[1] != ErrInvalidIDFormat {
+			t.Errorf("Expected invalid ID error for the second candidate, got: %v", result.Errors[1])
+		}
+		// 練習室一覧にない部屋は空いていないのではなく、見つからない
+		if !errors.Is(result.Errors[2], ErrRoomNotFound) || errors.Is(result.Errors[2], ErrSlotTaken) {
+			t.Errorf("Expected room not found error for the third candidate, got: %v", result.Errors[2])
+		}
+	})
+
+	t.Run("ExpandCandidates", func(t *testing.T) {
+		client := New()
+
+		candidates := client.ExpandReserveCandidates(GetRoomsFilteredParams{
+			PianoTypes: []RoomPianoType{RoomPianoTypeGrand},
+			Floors:     []int{4},
+			Campuses:   []Campus{CampusNakameguro},
+		}, candidate(""))
+
+		if len(candidates) == 0 {
+			t.Fatal("Expected candidates to be expanded")
+		}
+		for _, c := range candidates {
+			room, ok := client.GetRoomByID(c.RoomID)
+			if !ok || room.Campus != CampusIkebukuro || room.Floor != 4 || room.PianoType != RoomPianoTypeGrand {
+				t.Errorf("Unexpected candidate room: %+v", room)
+			}
+			if c.FromHour != 17 || c.ToHour != 22 {
+				t.Errorf("Expected times to be kept, got %+v", c)
+			}
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package tcmrsv
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+func (t AvailableTime) minutes() int {
+	return t.TimeOfDay().Minutes()
+}
+
+func availableTimeFromMinutes(m int) AvailableTime {
+	return timeOfDayFromMinutes(m).AvailableTime()
+}
+
+// 予約できる時間帯を探す範囲。From と To が同じ（ゼロ値を含む）なら開館時間全体
+type TimeWindow struct {
+	From AvailableTime
+	To   AvailableTime
+}
+
+func (w TimeWindow) bounds() (from, to int) {
+	if w.From == w.To {
+		return OpeningHours.From.Minutes(), OpeningHours.To.Minutes()
+	}
+	return w.From.minutes(), w.To.minutes()
+}
+
+// 練習室の連続して空いている時間帯。To は最後の枠の終了時刻
+type FreeBlock struct {
+	Room Room
+	From AvailableTime
+	To   AvailableTime
+}
+
+func (b FreeBlock) Duration() time.Duration {
+	return b.TimeRange().Duration()
+}
+
+// from から to まで（to は終了時刻）の枠がすべて空いているか
+func (a RoomAvailability) CanReserve(from, to AvailableTime) bool {
+	r := TimeRange{From: from.TimeOfDay(), To: to.TimeOfDay()}
+	if r.Validate() != nil {
+		return false
+	}
+
+	free := make(map[AvailableTime]bool, len(a.AvailableTimes))
+	for _, t := range a.AvailableTimes {
+		free[t] = true
+	}
+
+	for _, slot := range r.Slots() {
+		if !free[slot.AvailableTime()] {
+			return false
+		}
+	}
+	return true
+}
+
+// window の中で minDuration 以上連続して空いている時間帯を探す。
+// 開始時刻の早い順（同じなら長い順、さらに同じなら availabilities の順）に返す
+func FindFreeBlocks(availabilities []RoomAvailability, minDuration time.Duration, window TimeWindow) []FreeBlock {
+	windowFrom, windowTo := window.bounds()
+
+	var blocks []FreeBlock
+	for _, a := range availabilities {
+		starts := make([]int, 0, len(a.AvailableTimes))
+		for _, t := range a.AvailableTimes {
+			m := t.minutes()
+			if windowFrom <= m && m+30 <= windowTo {
+				starts = append(starts, m)
+			}
+		}
+		slices.Sort(starts)
+		starts = slices.Compact(starts)
+
+		for i := 0; i < len(starts); {
+			j := i + 1
+			for j < len(starts) && starts[j] == starts[j-1]+30 {
+				j++
+			}
+
+			block := FreeBlock{
+				Room: a.Room,
+				From: availableTimeFromMinutes(starts[i]),
+				To:   availableTimeFromMinutes(starts[j-1] + 30),
+			}
+			if block.Duration() >= minDuration {
+				blocks = append(blocks, block)
+			}
+			i = j
+		}
+	}
+
+	slices.SortStableFunc(blocks, func(a, b FreeBlock) int {
+		if c := cmp.Compare(a.From.minutes(), b.From.minutes()); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Duration(), a.Duration())
+	})
+	return blocks
+}
+
+// 長い順（同じなら元の順）に並べ替える
+func SortFreeBlocksByDuration(blocks []FreeBlock) {
+	slices.SortStableFunc(blocks, func(a, b FreeBlock) int {
+		return cmp.Compare(b.Duration(), a.Duration())
+	})
+}
@@ -0,0 +1,110 @@
+package tcmrsv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindFreeBlocks(t *testing.T) {
+	times := func(from, to AvailableTime) []AvailableTime {
+		var result []AvailableTime
+		for m := from.minutes(); m < to.minutes(); m += 30 {
+			result = append(result, availableTimeFromMinutes(m))
+		}
+		return result
+	}
+
+	a := RoomAvailability{
+		Room: Room{ID: "a", Name: "A"},
+		// 9:00〜10:00 と 13:00〜16:00
+		AvailableTimes: append(times(AvailableTime{9, 0}, AvailableTime{10, 0}), times(AvailableTime{13, 0}, AvailableTime{16, 0})...),
+	}
+	b := RoomAvailability{
+		Room: Room{ID: "b", Name: "B"},
+		// 13:00〜14:00 と 18:00〜23:00
+		AvailableTimes: append(times(AvailableTime{13, 0}, AvailableTime{14, 0}), times(AvailableTime{18, 0}, AvailableTime{23, 0})...),
+	}
+	availabilities := []RoomAvailability{a, b}
+
+	t.Run("WholeDay", func(t *testing.T) {
+		blocks := FindFreeBlocks(availabilities, 0, TimeWindow{})
+
+		expected := []FreeBlock{
+			{Room: a.Room, From: AvailableTime{9, 0}, To: AvailableTime{10, 0}},
+			{Room: a.Room, From: AvailableTime{13, 0}, To: AvailableTime{16, 0}},
+			{Room: b.Room, From: AvailableTime{13, 0}, To: AvailableTime{14, 0}},
+			{Room: b.Room, From: AvailableTime{18, 0}, To: AvailableTime{23, 0}},
+		}
+		if len(blocks) != len(expected) {
+			t.Fatalf("Expected %d blocks, got %v", len(expected), blocks)
+		}
+		for i := range expected {
+			if blocks[i] != expected[i] {
+				t.Errorf("Expected block %d to be %v, got %v", i, expected[i], blocks[i])
+			}
+		}
+	})
+
+	t.Run("MinDurationAndWindow", func(t *testing.T) {
+		// 14:00〜20:00 の間で 2 時間以上
+		blocks := FindFreeBlocks(availabilities, 2*time.Hour, TimeWindow{From: AvailableTime{14, 0}, To: AvailableTime{20, 0}})
+
+		expected := []FreeBlock{
+			{Room: a.Room, From: AvailableTime{14, 0}, To: AvailableTime{16, 0}},
+			{Room: b.Room, From: AvailableTime{18, 0}, To: AvailableTime{20, 0}},
+		}
+		if len(blocks) != len(expected) {
+			t.Fatalf("Expected %d blocks, got %v", len(expected), blocks)
+		}
+		for i := range expected {
+			if blocks[i] != expected[i] {
+				t.Errorf("Expected block %d to be %v, got %v", i, expected[i], blocks[i])
+			}
+		}
+	})
+
+	t.Run("SortByDuration", func(t *testing.T) {
+		blocks := FindFreeBlocks(availabilities, 0, TimeWindow{})
+		SortFreeBlocksByDuration(blocks)
+
+		if blocks[0].Room.ID != "b" || blocks[0].Duration() != 5*time.Hour {
+			t.Errorf("Expected the 5 hour block first, got %v", blocks[0])
+		}
+		if blocks[1].Duration() != 3*time.Hour {
+			t.Errorf("Expected the 3 hour block second, got %v", blocks[1])
+		}
+		// 同じ長さなら開始時刻の早い順のまま
+		if blocks[2].Room.ID != "a" || blocks[3].Room.ID != "b" {
+			t.Errorf("Expected equal blocks to keep their order, got %v", blocks[2:])
+		}
+	})
+}
+
+func TestCanReserve(t *testing.T) {
+	a := RoomAvailability{
+		Room:           Room{ID: "a", Name: "A"},
+		AvailableTimes: []AvailableTime{{13, 0}, {13, 30}, {14, 0}, {15, 0}, {22, 30}},
+	}
+
+	tests := []struct {
+		from, to AvailableTime
+		expected bool
+	}{
+		{AvailableTime{13, 0}, AvailableTime{14, 30}, true},
+		{AvailableTime{13, 30}, AvailableTime{14, 0}, true},
+		{AvailableTime{22, 30}, AvailableTime{23, 0}, true},
+		// 14:30 が埋まっている
+		{AvailableTime{14, 0}, AvailableTime{15, 30}, false},
+		{AvailableTime{12, 30}, AvailableTime{13, 30}, false},
+		// 不正な時間帯
+		{AvailableTime{14, 0}, AvailableTime{13, 0}, false},
+		{AvailableTime{13, 0}, AvailableTime{13, 0}, false},
+		{AvailableTime{13, 15}, AvailableTime{14, 0}, false},
+	}
+
+	for _, tt := range tests {
+		if got := a.CanReserve(tt.from, tt.to); got != tt.expected {
+			t.Errorf("CanReserve(%v, %v) = %v, expected %v", tt.from, tt.to, got, tt.expected)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package tcmrsv
 
+import "fmt"
+
 type Campus string
 
 const (
@@ -8,6 +10,19 @@ const (
 	CampusNakameguro Campus = "2"
 )
 
+var campusNames = map[string]Campus{
+	"池袋キャンパス":      CampusIkebukuro,
+	"中目黒・代官山キャンパス": CampusNakameguro,
+}
+
+// 画面に表示されるキャンパス名から Campus を求める
+func campusFromName(name string) Campus {
+	if campus, ok := campusNames[name]; ok {
+		return campus
+	}
+	return CampusUnknown
+}
+
 func (c Campus) IsValid() bool {
 	switch c {
 	case CampusIkebukuro, CampusNakameguro:
@@ -36,14 +51,14 @@ func (r RoomPianoType) IsValid() bool {
 }
 
 type Room struct {
-	ID          string
-	Name        string
-	PianoType   RoomPianoType
-	PianoNumber int
-	IsClassroom bool
-	IsBasement  bool
-	Campus      Campus
-	Floor       int
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	PianoType   RoomPianoType `json:"piano_type"`
+	PianoNumber int           `json:"piano_number"`
+	IsClassroom bool          `json:"is_classroom"`
+	IsBasement  bool          `json:"is_basement"`
+	Campus      Campus        `json:"campus"`
+	Floor       int           `json:"floor"`
 }
 
 type AvailableTime struct {
@@ -66,4 +81,50 @@ type Reservation struct {
 	FromMinute int
 	ToHour     int
 	ToMinute   int
+	// 練習室一覧から部屋名で特定した部屋（一覧にない場合は nil）
+	Room *Room
+	// 予約は完了したが、予約一覧から特定できなかった（ID は空で、内容は予約時の指定から埋める）
+	Unresolved bool
+	// 特定できなかった理由（ErrReservationNotFound、ErrAmbiguousReservation、予約一覧の取得エラー）
+	ResolveErr error
+}
+
+// 予約の部屋を返す。練習室一覧にない部屋の場合は部屋名を含む ErrRoomNotFound を返す
+func (r *Reservation) ResolvedRoom() (*Room, error) {
+	if r.Room == nil {
+		return nil, fmt.Errorf("room %q: %w", r.RoomName, ErrRoomNotFound)
+	}
+	return r.Room, nil
+}
+
+// 予約ページの 30 分枠 1 つ分の状態
+type SlotState string
+
+const (
+	SlotStateFree             SlotState = "free"
+	SlotStateReservedByOthers SlotState = "reserved_by_others"
+	SlotStateReservedByMe     SlotState = "reserved_by_me"
+	// 開館時間内だが、空いているかは分からない（トップページの予約状況表）
+	SlotStateOpen     SlotState = "open"
+	SlotStateDisabled SlotState = "disabled"
+)
+
+func (s SlotState) IsValid() bool {
+	switch s {
+	case SlotStateFree, SlotStateReservedByOthers, SlotStateReservedByMe, SlotStateOpen, SlotStateDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
+type ScheduleSlot struct {
+	Hour   int
+	Minute int
+	State  SlotState
+}
+
+type RoomSchedule struct {
+	Room  Room
+	Slots []ScheduleSlot
 }
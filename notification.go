@@ -0,0 +1,169 @@
+package tcmrsv
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// トップページに掲載されるお知らせ
+type Notification struct {
+	Date  Date
+	Title string
+	// 本文（<br/> は改行に変換される）
+	Body string
+}
+
+func (n Notification) Equals(other Notification) bool {
+	return n.Date.Equals(other.Date) && n.Title == other.Title && n.Body == other.Body
+}
+
+// お知らせ一覧を取得する
+func (c *Client) GetNotifications() ([]Notification, error) {
+	return c.GetNotificationsContext(context.Background())
+}
+
+func (c *Client) GetNotificationsContext(ctx context.Context) ([]Notification, error) {
+	var notifications []Notification
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		notifications, err = c.getNotifications(ctx)
+		return err
+	}))
+	return notifications, err
+}
+
+func (c *Client) getNotifications(ctx context.Context) ([]Notification, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+ENDPOINT_INDEX, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return parseNotifications(res.Body)
+}
+
+func parseNotifications(r io.Reader) ([]Notification, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		notifications      []Notification
+		current            *Notification
+		body               strings.Builder
+		insideNotification bool
+		divDepth           int
+		currentClass       string
+	)
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return notifications, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+
+			switch t.Data {
+			case "div":
+				if insideNotification {
+					divDepth++
+				}
+				if attrs["id"] == "notification" {
+					insideNotification = true
+					divDepth = 1
+				}
+			case "dl":
+				if insideNotification {
+					notifications = append(notifications, Notification{})
+					current = &notifications[len(notifications)-1]
+					body.Reset()
+				}
+			case "span":
+				currentClass = attrs["class"]
+			case "br":
+				if current != nil && currentClass == "report" {
+					body.WriteString("\n")
+				}
+			}
+
+		case html.TextToken:
+			if current == nil {
+				continue
+			}
+			text := strings.TrimSpace(z.Token().Data)
+			if text == "" {
+				continue
+			}
+
+			switch currentClass {
+			case "date":
+				if d, err := time.ParseInLocation("2006/01/02", text, jst); err == nil {
+					current.Date = FromTime(d)
+				}
+			case "title":
+				current.Title += text
+			case "report":
+				body.WriteString(text)
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "span":
+				currentClass = ""
+			case "dl":
+				if current != nil {
+					current.Body = strings.TrimSpace(body.String())
+					current = nil
+				}
+			case "div":
+				if insideNotification {
+					divDepth--
+					if divDepth == 0 {
+						insideNotification = false
+					}
+				}
+			}
+		}
+	}
+}
+
+// 前回取得したお知らせ一覧と比べて、追加されたものと削除されたものを返す
+func DiffNotifications(previous, current []Notification) (added, removed []Notification) {
+	contains := func(list []Notification, n Notification) bool {
+		for _, other := range list {
+			if n.Equals(other) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, n := range current {
+		if !contains(previous, n) {
+			added = append(added, n)
+		}
+	}
+	for _, n := range previous {
+		if !contains(current, n) {
+			removed = append(removed, n)
+		}
+	}
+	return added, removed
+}
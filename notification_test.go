@@ -0,0 +1,64 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetNotifications(t *testing.T) {
+	t.Run("SuccessfulNotificationRetrieval", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		notifications, err := mockServer.Client.GetNotifications()
+		if err != nil {
+			t.Fatalf("Expected successful notification retrieval, got error: %v", err)
+		}
+
+		if len(notifications) != 1 {
+			t.Fatalf("Expected 1 notification, got %d", len(notifications))
+		}
+
+		n := notifications[0]
+		if !n.Date.Equals(NewDate(2024, time.March, 29)) {
+			t.Errorf("Expected date to be 2024/03/29, got %v", n.Date)
+		}
+		if n.Title != "【全体へのお知らせ】" {
+			t.Errorf("Expected title to be 【全体へのお知らせ】, got %s", n.Title)
+		}
+
+		expectedBody := "練習室予約サイト内の「アカウント設定」からメールアドレスを設定してください。設定することで予約確定メールが届きます。\n" +
+			"予約画面をスクリーンショットしている方がいますが、稀に予約が取れていないことがあります。\n" +
+			"予約確定メールが届くことで確実に予約出来た証明になりますので、設定してください。"
+		if n.Body != expectedBody {
+			t.Errorf("Unexpected body: %q", n.Body)
+		}
+	})
+}
+
+func TestDiffNotifications(t *testing.T) {
+	old := Notification{Date: NewDate(2024, time.March, 29), Title: "【全体へのお知らせ】", Body: "メールアドレスを設定してください。"}
+	kept := Notification{Date: NewDate(2024, time.April, 1), Title: "【池袋キャンパス】", Body: "工事のお知らせ"}
+	fresh := Notification{Date: NewDate(2024, time.April, 10), Title: "【全体へのお知らせ】", Body: "利用規約を改定しました。"}
+
+	added, removed := DiffNotifications([]Notification{old, kept}, []Notification{fresh, kept})
+
+	if len(added) != 1 || !added[0].Equals(fresh) {
+		t.Errorf("Expected only the new notification to be added, got %v", added)
+	}
+	if len(removed) != 1 || !removed[0].Equals(old) {
+		t.Errorf("Expected only the old notification to be removed, got %v", removed)
+	}
+
+	added, removed = DiffNotifications([]Notification{kept}, []Notification{kept})
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected no difference, got added=%v removed=%v", added, removed)
+	}
+}
@@ -0,0 +1,197 @@
+package tcmrsv
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// トップページの予約状況表の 1 行分（キャンパス・日付・ピアノの種類ごと）
+type CampusOverview struct {
+	Campus    Campus
+	Date      Date
+	PianoType RoomPianoType
+	Slots     []ScheduleSlot
+}
+
+// 開館している 30 分枠。予約状況表からは空いているかが分からないため、
+// 空き状況は GetRoomAvailability で確認する
+func (o *CampusOverview) OpenTimes() []AvailableTime {
+	var times []AvailableTime
+	for _, slot := range o.Slots {
+		if slot.State == SlotStateOpen {
+			times = append(times, AvailableTime{Hour: slot.Hour, Minute: slot.Minute})
+		}
+	}
+	return times
+}
+
+// 予約状況表のテーブル ID とキャンパスの対応
+var overviewTables = map[string]Campus{
+	"tblMeDi": CampusNakameguro,
+	"tblIke":  CampusIkebukuro,
+}
+
+// トップページの予約状況表から、キャンパス・日付・ピアノの種類ごとの開館時間と自分の予約を取得する
+func (c *Client) GetCampusOverview() ([]CampusOverview, error) {
+	return c.GetCampusOverviewContext(context.Background())
+}
+
+func (c *Client) GetCampusOverviewContext(ctx context.Context) ([]CampusOverview, error) {
+	var overviews []CampusOverview
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		overviews, err = c.getCampusOverview(ctx)
+		return err
+	}))
+	return overviews, err
+}
+
+func (c *Client) getCampusOverview(ctx context.Context) ([]CampusOverview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+ENDPOINT_INDEX, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return parseCampusOverview(res.Body)
+}
+
+func parseCampusOverview(r io.Reader) ([]CampusOverview, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		overviews     []CampusOverview
+		current       *CampusOverview
+		date          Date
+		insideTable   bool
+		insidePiano   bool
+		slotIndex     int
+		tableDepth    int
+		hasDate       bool
+		currentCampus Campus
+	)
+
+	const baseHour = 7
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return overviews, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+
+			switch t.Data {
+			case "table":
+				if insideTable {
+					tableDepth++
+					break
+				}
+				if c, ok := overviewTables[attrs["id"]]; ok {
+					insideTable = true
+					tableDepth = 1
+					currentCampus = c
+					hasDate = false
+				}
+
+			case "tr":
+				if insideTable {
+					current = nil
+					slotIndex = 0
+				}
+
+			case "a":
+				// 日付は reserve.aspx へのリンクの ymd から取得する
+				if !insideTable {
+					break
+				}
+				u, err := url.Parse(attrs["href"])
+				if err != nil {
+					break
+				}
+				ymd, _, _ := strings.Cut(u.Query().Get("ymd"), " ")
+				if d, err := time.ParseInLocation("2006/1/2", ymd, jst); err == nil {
+					date = FromTime(d)
+					hasDate = true
+				}
+
+			case "td":
+				if !insideTable {
+					break
+				}
+				switch attrs["class"] {
+				case "g", "a":
+					insidePiano = true
+				default:
+					if current == nil {
+						break
+					}
+					if state, ok := slotStateFromClass(attrs["class"], false); ok {
+						offset := slotIndex
+						slotIndex++
+						current.Slots = append(current.Slots, ScheduleSlot{
+							Hour:   baseHour + (offset*30)/60,
+							Minute: (offset * 30) % 60,
+							State:  state,
+						})
+					}
+				}
+
+			case "span":
+				if !insidePiano || !hasDate {
+					break
+				}
+				if z.Next() != html.TextToken {
+					break
+				}
+				pianoType := RoomPianoTypeUnknown
+				switch strings.TrimSpace(z.Token().Data) {
+				case "グランドピアノ":
+					pianoType = RoomPianoTypeGrand
+				case "アップライト":
+					pianoType = RoomPianoTypeUpright
+				}
+				overviews = append(overviews, CampusOverview{
+					Campus:    currentCampus,
+					Date:      date,
+					PianoType: pianoType,
+				})
+				current = &overviews[len(overviews)-1]
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "td":
+				insidePiano = false
+			case "tr":
+				current = nil
+			case "table":
+				if insideTable {
+					tableDepth--
+					if tableDepth == 0 {
+						insideTable = false
+					}
+				}
+			}
+		}
+	}
+}
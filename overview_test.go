@@ -0,0 +1,85 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetCampusOverview(t *testing.T) {
+	t.Run("SuccessfulOverviewRetrieval", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		overviews, err := mockServer.Client.GetCampusOverview()
+		if err != nil {
+			t.Fatalf("Expected successful overview retrieval, got error: %v", err)
+		}
+
+		// 2 キャンパス × 2 日 × 2 種類のピアノ
+		if len(overviews) != 8 {
+			t.Fatalf("Expected 8 overviews, got %d", len(overviews))
+		}
+
+		for _, o := range overviews {
+			if len(o.Slots) != 32 {
+				t.Errorf("Expected 32 slots for %s %v %s, got %d", o.Campus, o.Date, o.PianoType, len(o.Slots))
+			}
+		}
+
+		first := overviews[0]
+		if first.Campus != CampusNakameguro || !first.Date.Equals(NewDate(2025, time.May, 4)) || first.PianoType != RoomPianoTypeGrand {
+			t.Errorf("Unexpected first overview: %+v", first)
+		}
+
+		// 10:00 から 17:00 まで開館している
+		times := first.OpenTimes()
+		if len(times) != 14 || times[0] != (AvailableTime{Hour: 10, Minute: 0}) {
+			t.Errorf("Unexpected open times: %v", times)
+		}
+		for _, slot := range first.Slots {
+			if slot.State == SlotStateFree {
+				t.Errorf("Expected no slot to be reported free, got %02d:%02d", slot.Hour, slot.Minute)
+			}
+		}
+
+		// 池袋キャンパス 5 月 5 日のグランドピアノは 17:00 から自分の予約が入っている
+		var ikebukuro *CampusOverview
+		for i := range overviews {
+			o := &overviews[i]
+			if o.Campus == CampusIkebukuro && o.Date.Equals(NewDate(2025, time.May, 5)) && o.PianoType == RoomPianoTypeGrand {
+				ikebukuro = o
+			}
+		}
+		if ikebukuro == nil {
+			t.Fatal("Expected overview for Ikebukuro on 2025-05-05")
+		}
+		for _, slot := range ikebukuro.Slots {
+			if slot.Hour == 17 && slot.Minute == 0 && slot.State != SlotStateReservedByMe {
+				t.Errorf("Expected 17:00 to be reserved by me, got %s", slot.State)
+			}
+		}
+	})
+
+	t.Run("AuthenticationFailure", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetCampusOverview()
+		if err != ErrAuthenticationFailed {
+			t.Errorf("Expected authentication failure, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package tcmrsv
+
+import "time"
+
+// 予約を受け付ける日付の規則
+type BookingPolicy struct {
+	// ReleaseHour:ReleaseMinute 以降に予約できる最も先の日付（今日から何日後か）
+	DaysAhead int
+	// ReleaseHour:ReleaseMinute より前は DaysAhead-1 日後まで予約できる（日本時間）
+	ReleaseHour   int
+	ReleaseMinute int
+}
+
+// 今日から 2 日後までの予約を受け付け、2 日後の予約は 12:00 に解放される
+func DefaultBookingPolicy() BookingPolicy {
+	return BookingPolicy{
+		DaysAhead:     2,
+		ReleaseHour:   12,
+		ReleaseMinute: 0,
+	}
+}
+
+// now の日の解放時刻
+func (p BookingPolicy) releaseTime(now time.Time) time.Time {
+	now = now.In(jst)
+	return time.Date(now.Year(), now.Month(), now.Day(), p.ReleaseHour, p.ReleaseMinute, 0, 0, jst)
+}
+
+// now の時点で予約できる最も前の日付と最も先の日付
+func (p BookingPolicy) BookingWindow(now time.Time) (earliest, latest Date) {
+	today := FromTime(now)
+
+	days := p.DaysAhead
+	if now.Before(p.releaseTime(now)) {
+		days--
+	}
+
+	return today, today.AddDays(days)
+}
+
+// now より後で、次に新しい日付が予約できるようになる時刻
+// その時刻に解放される日付は BookingWindow(NextReleaseTime(now)) の latest になる
+func (p BookingPolicy) NextReleaseTime(now time.Time) time.Time {
+	release := p.releaseTime(now)
+	if now.Before(release) {
+		return release
+	}
+	return release.AddDate(0, 0, 1)
+}
+
+// now の時点で date を予約できるか
+func (p BookingPolicy) IsDateBookable(now time.Time, date Date) bool {
+	earliest, latest := p.BookingWindow(now)
+	return !date.IsBefore(earliest) && !date.IsAfter(latest)
+}
+
+// DefaultBookingPolicy で now の時点で予約できる日付の範囲を返す
+func BookingWindow(now time.Time) (earliest, latest Date) {
+	return DefaultBookingPolicy().BookingWindow(now)
+}
+
+// DefaultBookingPolicy で次に新しい日付が予約できるようになる時刻を返す
+func NextReleaseTime(now time.Time) time.Time {
+	return DefaultBookingPolicy().NextReleaseTime(now)
+}
@@ -0,0 +1,82 @@
+package tcmrsv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBookingWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		earliest Date
+		latest   Date
+	}{
+		{"Midnight", time.Date(2025, time.May, 4, 0, 0, 0, 0, jst), NewDate(2025, time.May, 4), NewDate(2025, time.May, 5)},
+		{"BeforeNoon", time.Date(2025, time.May, 4, 11, 59, 59, 0, jst), NewDate(2025, time.May, 4), NewDate(2025, time.May, 5)},
+		{"Noon", time.Date(2025, time.May, 4, 12, 0, 0, 0, jst), NewDate(2025, time.May, 4), NewDate(2025, time.May, 6)},
+		{"Evening", time.Date(2025, time.May, 4, 23, 59, 0, 0, jst), NewDate(2025, time.May, 4), NewDate(2025, time.May, 6)},
+		// UTC で渡されても日本時間で判定する（UTC 3:00 = JST 12:00）
+		{"UTC", time.Date(2025, time.May, 4, 3, 0, 0, 0, time.UTC), NewDate(2025, time.May, 4), NewDate(2025, time.May, 6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earliest, latest := BookingWindow(tt.now)
+			if !earliest.Equals(tt.earliest) || !latest.Equals(tt.latest) {
+				t.Errorf("BookingWindow(%v) = %v, %v; want %v, %v", tt.now, earliest, latest, tt.earliest, tt.latest)
+			}
+		})
+	}
+}
+
+func TestNextReleaseTime(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"BeforeNoon", time.Date(2025, time.May, 4, 11, 59, 0, 0, jst), time.Date(2025, time.May, 4, 12, 0, 0, 0, jst)},
+		{"Noon", time.Date(2025, time.May, 4, 12, 0, 0, 0, jst), time.Date(2025, time.May, 5, 12, 0, 0, 0, jst)},
+		{"EndOfMonth", time.Date(2025, time.May, 31, 18, 0, 0, 0, jst), time.Date(2025, time.June, 1, 12, 0, 0, 0, jst)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextReleaseTime(tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextReleaseTime(%v) = %v; want %v", tt.now, got, tt.want)
+			}
+
+			// 解放時刻には新しい日付が予約できるようになる
+			_, before := BookingWindow(got.Add(-time.Nanosecond))
+			_, after := BookingWindow(got)
+			if !after.Equals(before.AddDays(1)) {
+				t.Errorf("Expected %v to become bookable at %v, got %v", before.AddDays(1), got, after)
+			}
+		})
+	}
+}
+
+func TestBookingPolicy(t *testing.T) {
+	policy := BookingPolicy{DaysAhead: 7, ReleaseHour: 9, ReleaseMinute: 30}
+	now := time.Date(2025, time.May, 4, 9, 0, 0, 0, jst)
+
+	if _, latest := policy.BookingWindow(now); !latest.Equals(NewDate(2025, time.May, 10)) {
+		t.Errorf("Expected latest date to be 2025-05-10, got %v", latest)
+	}
+	if got := policy.NextReleaseTime(now); !got.Equal(time.Date(2025, time.May, 4, 9, 30, 0, 0, jst)) {
+		t.Errorf("Expected next release at 9:30, got %v", got)
+	}
+	if policy.IsDateBookable(now, NewDate(2025, time.May, 3)) {
+		t.Error("Expected past date not to be bookable")
+	}
+
+	client := New(WithBookingPolicy(policy), WithClock(NewFakeClock(now)))
+	if _, latest := client.BookingWindow(); !latest.Equals(NewDate(2025, time.May, 10)) {
+		t.Errorf("Expected client to use the supplied policy, got %v", latest)
+	}
+	if got := client.NextReleaseTime(); !got.Equal(time.Date(2025, time.May, 4, 9, 30, 0, 0, jst)) {
+		t.Errorf("Expected client to use the supplied policy, got %v", got)
+	}
+}
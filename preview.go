@@ -0,0 +1,193 @@
+package tcmrsv
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// 予約内容確認ページに表示された内容
+type ReservationPreview struct {
+	Campus     Campus
+	CampusName string
+	Date       Date
+	RoomName   string
+	FromHour   int
+	FromMinute int
+	ToHour     int
+	ToMinute   int
+	// サーバーが赤字などで表示したエラーメッセージ（例: 指定した時間は予約できません。）
+	ErrorMessage string
+}
+
+// 予約を確定できる状態か
+func (p *ReservationPreview) IsReservable() bool {
+	return p.ErrorMessage == ""
+}
+
+// 予約内容確認ページまで進み、予約を確定せずにサーバーの表示内容を返す
+func (c *Client) PreviewReservation(params *ReserveParams) (*ReservationPreview, error) {
+	return c.PreviewReservationContext(context.Background(), params)
+}
+
+func (c *Client) PreviewReservationContext(ctx context.Context, params *ReserveParams) (*ReservationPreview, error) {
+	if err := params.validate(c.now(), c.bookingPolicy); err != nil {
+		return nil, err
+	}
+
+	var preview *ReservationPreview
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		preview, err = c.previewReservation(ctx, params)
+		return err
+	}))
+	return preview, err
+}
+
+func (c *Client) previewReservation(ctx context.Context, params *ReserveParams) (*ReservationPreview, error) {
+	confirmsURL, err := params.confirmsURL(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, confirmsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return parseReservationPreview(res.Body)
+}
+
+var (
+	previewDateRegex = regexp.MustCompile(`(\d{4})\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*日`)
+	previewTimeRegex = regexp.MustCompile(`(\d{1,2})\s*:\s*(\d{2})\s*-\s*(\d{1,2})\s*:\s*(\d{2})`)
+)
+
+func parseReservationPreview(r io.Reader) (*ReservationPreview, error) {
+	bodyBytes, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseReservationEntries(bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var preview ReservationPreview
+	if len(entries) > 0 {
+		preview = entries[0]
+	}
+	// 赤字のメッセージはエラーページと同じ方法で取り出す
+	preview.ErrorMessage, _ = extractServerMessage(bytes.NewReader(bodyBytes))
+
+	return &preview, nil
+}
+
+// 確認ページや完了ページの #reservation-list に並ぶ予約内容を取り出す
+func parseReservationEntries(r io.Reader) ([]ReservationPreview, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		entries               []ReservationPreview
+		current               *ReservationPreview
+		insideReservationList bool
+		currentTag            string
+		divDepth              int
+	)
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return entries, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+
+			switch t.Data {
+			case "div":
+				if insideReservationList {
+					divDepth++
+				}
+				if attrs["id"] == "reservation-list" {
+					insideReservationList = true
+					divDepth = 1
+				}
+			case "dl":
+				if insideReservationList {
+					entries = append(entries, ReservationPreview{})
+					current = &entries[len(entries)-1]
+				}
+			case "dt", "dd":
+				currentTag = t.Data + "." + attrs["class"]
+			}
+
+		case html.TextToken:
+			// 改行やインデントで分割された表示内容を 1 行にまとめる
+			text := strings.Join(strings.Fields(z.Token().Data), " ")
+			if text == "" {
+				continue
+			}
+
+			if !insideReservationList || current == nil {
+				continue
+			}
+
+			switch {
+			case strings.HasSuffix(text, "キャンパス"):
+				current.CampusName = text
+				current.Campus = campusFromName(text)
+			case currentTag == "dd.res-time":
+				if m := previewTimeRegex.FindStringSubmatch(text); m != nil {
+					current.FromHour, _ = strconv.Atoi(m[1])
+					current.FromMinute, _ = strconv.Atoi(m[2])
+					current.ToHour, _ = strconv.Atoi(m[3])
+					current.ToMinute, _ = strconv.Atoi(m[4])
+				}
+			case currentTag == "dd.res-room":
+				current.RoomName = text
+			default:
+				if m := previewDateRegex.FindStringSubmatch(text); m != nil {
+					year, _ := strconv.Atoi(m[1])
+					month, _ := strconv.Atoi(m[2])
+					day, _ := strconv.Atoi(m[3])
+					current.Date = NewDate(year, time.Month(month), day)
+				}
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "dl":
+				current = nil
+			case "div":
+				if insideReservationList {
+					divDepth--
+					if divDepth == 0 {
+						insideReservationList = false
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPreviewReservation(t *testing.T) {
+	params := &ReserveParams{
+		Campus:     CampusNakameguro,
+		RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6", // P 200（G）
+		Date:       Today().AddDays(1),
+		FromHour:   10,
+		FromMinute: 30,
+		ToHour:     11,
+		ToMinute:   30,
+	}
+
+	t.Run("SuccessfulPreview", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("Reservation must not be confirmed in preview")
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		preview, err := mockServer.Client.PreviewReservation(params)
+		if err != nil {
+			t.Fatalf("Expected successful preview, got error: %v", err)
+		}
+
+		if preview.Campus != CampusNakameguro {
+			t.Errorf("Expected campus to be Nakameguro, got %v", preview.Campus)
+		}
+
+		if preview.Date != NewDate(2025, 5, 4) {
+			t.Errorf("Expected date to be 2025-05-04, got %s", preview.Date)
+		}
+
+		if !(preview.FromHour == 10 && preview.FromMinute == 30 && preview.ToHour == 11 && preview.ToMinute == 30) {
+			t.Errorf("Expected time range to be 10:30-11:30, got %02d:%02d-%02d:%02d", preview.FromHour, preview.FromMinute, preview.ToHour, preview.ToMinute)
+		}
+
+		if preview.RoomName != "P 200（G）" {
+			t.Errorf("Expected room name to be P 200（G）, got %s", preview.RoomName)
+		}
+
+		if !preview.IsReservable() {
+			t.Errorf("Expected preview to be reservable, got error message %q", preview.ErrorMessage)
+		}
+
+		if len(mockServer.Requests) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(mockServer.Requests))
+		}
+	})
+
+	t.Run("ErrorMessage", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms_failure.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		preview, err := mockServer.Client.PreviewReservation(params)
+		if err != nil {
+			t.Fatalf("Expected successful preview, got error: %v", err)
+		}
+
+		if preview.ErrorMessage != "指定した時間は予約できません。" {
+			t.Errorf("Expected error message to be 指定した時間は予約できません。, got %q", preview.ErrorMessage)
+		}
+
+		if preview.IsReservable() {
+			t.Error("Expected preview not to be reservable")
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// このハンドラーは入力検証エラーのため呼び出されるべきではない
+			t.Errorf("Handler called despite validation errors")
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.PreviewReservation(&ReserveParams{
+			Campus:     CampusUnknown,
+			RoomID:     params.RoomID,
+			Date:       params.Date,
+			FromHour:   params.FromHour,
+			FromMinute: params.FromMinute,
+			ToHour:     params.ToHour,
+			ToMinute:   params.ToMinute,
+		})
+
+		if err != ErrInvalidCampus {
+			t.Errorf("Expected invalid campus error, got: %v", err)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package tcmrsv
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,7 +16,20 @@ import (
 )
 
 func (c *Client) GetMyReservations() ([]Reservation, error) {
-	req, err := http.NewRequest(http.MethodGet, c.baseURL+ENDPOINT_INDEX, nil)
+	return c.GetMyReservationsContext(context.Background())
+}
+
+func (c *Client) GetMyReservationsContext(ctx context.Context) ([]Reservation, error) {
+	var reservations []Reservation
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		reservations, err = c.getMyReservations(ctx)
+		return err
+	}))
+	return reservations, err
+}
+
+func (c *Client) getMyReservations(ctx context.Context) ([]Reservation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+ENDPOINT_INDEX, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -33,11 +49,6 @@ func (c *Client) GetMyReservations() ([]Reservation, error) {
 		currentTag            string
 	)
 
-	var campusMap = map[string]Campus{
-		"池袋キャンパス":      CampusIkebukuro,
-		"中目黒・代官山キャンパス": CampusNakameguro,
-	}
-
 	for {
 		tt := z.Next()
 		switch tt {
@@ -102,11 +113,7 @@ func (c *Client) GetMyReservations() ([]Reservation, error) {
 				if text != "" {
 					switch currentTag {
 					case "campus":
-						if campus, ok := campusMap[text]; ok {
-							currentReservation.Campus = campus
-						} else {
-							currentReservation.Campus = CampusUnknown
-						}
+						currentReservation.Campus = campusFromName(text)
 						currentReservation.CampusName = text
 					case "res-date":
 						layout := "2006年01月02日"
@@ -149,6 +156,9 @@ func (c *Client) GetMyReservations() ([]Reservation, error) {
 			if t.Data == "dl" && insideReservationList && currentReservation != nil {
 				// 変な空構造体が入るので簡単に検証
 				if currentReservation.CampusName != "" || currentReservation.ID != "" {
+					if room, ok := c.index().findByName(currentReservation.Campus, currentReservation.RoomName); ok {
+						currentReservation.Room = &room
+					}
 					reservations = append(reservations, *currentReservation)
 				}
 				currentReservation = nil
@@ -167,76 +177,195 @@ type ReserveParams struct {
 	ToMinute   int
 }
 
-func (c *Client) Reserve(params *ReserveParams) error {
-	if !params.Campus.IsValid() {
+// 予約を作成し、予約一覧から作成された予約を特定して返す。
+// 予約自体は完了したが予約一覧の取得に失敗した場合や特定できなかった場合も、エラーは返さない。
+// その場合の Reservation は Unresolved が true で ID が空になり、理由は ResolveErr に入る。
+// ID が必要なら GetMyReservations で確認する
+func (c *Client) Reserve(params *ReserveParams) (*Reservation, error) {
+	return c.ReserveContext(context.Background(), params)
+}
+
+func (c *Client) ReserveContext(ctx context.Context, params *ReserveParams) (*Reservation, error) {
+	if err := params.validate(c.now(), c.bookingPolicy); err != nil {
+		return nil, err
+	}
+
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) error {
+		return c.reserve(ctx, params)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.resolveReservation(ctx, params), nil
+}
+
+// 作成した予約を予約一覧から特定する。特定できなければ Unresolved の Reservation を返す
+func (c *Client) resolveReservation(ctx context.Context, params *ReserveParams) *Reservation {
+	var room *Room
+	if r, ok := c.GetRoomByID(params.RoomID); ok {
+		room = &r
+	}
+
+	// 予約の再送を防ぐため、予約一覧の取得は別に再試行する
+	reservations, err := c.GetMyReservationsContext(ctx)
+	if err == nil {
+		var reservation *Reservation
+		reservation, err = findReservation(reservations, params, room)
+		if err == nil {
+			return reservation
+		}
+	}
+
+	reservation := params.reservation(room)
+	reservation.Unresolved = true
+	reservation.ResolveErr = err
+	return reservation
+}
+
+func findReservation(reservations []Reservation, params *ReserveParams, room *Room) (*Reservation, error) {
+	var matched []Reservation
+	for _, r := range reservations {
+		if r.Campus != params.Campus || !r.Date.Equals(params.Date) {
+			continue
+		}
+		if r.FromHour != params.FromHour || r.FromMinute != params.FromMinute || r.ToHour != params.ToHour || r.ToMinute != params.ToMinute {
+			continue
+		}
+		// カタログにない部屋は部屋名で絞り込めない
+		if room != nil && NormalizeRoomName(r.RoomName) != NormalizeRoomName(room.Name) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	switch len(matched) {
+	case 1:
+		return &matched[0], nil
+	case 0:
+		return nil, ErrReservationNotFound
+	default:
+		return nil, ErrAmbiguousReservation
+	}
+}
+
+func (p *ReserveParams) validate(now time.Time, policy BookingPolicy) error {
+	if !p.Campus.IsValid() {
 		return ErrInvalidCampus
 	}
-	if !IsIDValid(params.RoomID) {
+	if !IsIDValid(p.RoomID) {
 		return ErrInvalidIDFormat
 	}
-	if !IsDateWithin2Days(time.Now().In(jst), params.Date) {
+	if !policy.IsDateBookable(now, p.Date) {
 		return ErrDateOutOfRange
 	}
-	if !IsTimeRangeValid(params.FromHour, params.FromMinute, params.ToHour, params.ToMinute) {
+	if !IsTimeRangeValid(p.FromHour, p.FromMinute, p.ToHour, p.ToMinute) {
 		return ErrInvalidTimeRange
 	}
-	if !IsTimeInFuture(params.FromHour, params.FromMinute, params.Date) {
+	if !IsTimeInFutureAt(now, p.FromHour, p.FromMinute, p.Date) {
 		return ErrTimeInPast
 	}
+	return nil
+}
 
-	u, err := url.Parse(c.baseURL + ENDPOINT_CONFIRMS)
+// 予約内容確認ページの URL
+func (p *ReserveParams) confirmsURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL + ENDPOINT_CONFIRMS)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	q := u.Query()
-	q.Set("campus", string(params.Campus))
-	q.Set("room", params.RoomID)
-	q.Set("ymd", params.Date.ToTime().Format("2006/01/02 15:04:05"))
-	q.Set("fromh", fmt.Sprintf("%02d", params.FromHour))
-	q.Set("fromm", fmt.Sprintf("%02d", params.FromMinute))
-	q.Set("toh", fmt.Sprintf("%02d", params.ToHour))
-	q.Set("tom", fmt.Sprintf("%02d", params.ToMinute))
+	q.Set("campus", string(p.Campus))
+	q.Set("room", p.RoomID)
+	q.Set("ymd", p.Date.ToTime().Format("2006/01/02 15:04:05"))
+	q.Set("fromh", fmt.Sprintf("%02d", p.FromHour))
+	q.Set("fromm", fmt.Sprintf("%02d", p.FromMinute))
+	q.Set("toh", fmt.Sprintf("%02d", p.ToHour))
+	q.Set("tom", fmt.Sprintf("%02d", p.ToMinute))
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	return u.String(), nil
+}
+
+func (p *ReserveParams) reservation(room *Room) *Reservation {
+	reservation := &Reservation{
+		Campus:     p.Campus,
+		Date:       p.Date,
+		FromHour:   p.FromHour,
+		FromMinute: p.FromMinute,
+		ToHour:     p.ToHour,
+		ToMinute:   p.ToMinute,
+		Room:       room,
+	}
+	if room != nil {
+		reservation.RoomName = room.Name
+	}
+	return reservation
+}
+
+func (c *Client) reserve(ctx context.Context, params *ReserveParams) error {
+	confirmsURL, aspConfig, err := c.prepareReservation(ctx, params)
 	if err != nil {
 		return err
 	}
 
-	res, err := c.DoRequest(req, true)
+	_, err = c.confirmReservation(ctx, confirmsURL, aspConfig)
+	return err
+}
+
+// 予約内容確認ページを開き、予約の確定に使うトークンを取得する
+func (c *Client) prepareReservation(ctx context.Context, params *ReserveParams) (string, *ASPConfig, error) {
+	confirmsURL, err := params.confirmsURL(c.baseURL)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	defer res.Body.Close()
 
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, confirmsURL, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	res, aspConfig, err := c.doRequest(req, true)
+	if err != nil {
+		return "", nil, err
+	}
+	res.Body.Close()
+
+	return confirmsURL, aspConfig, nil
+}
+
+// 予約内容確認ページで KakuteiButton を送信して予約を確定する
+// 失敗した場合も、送り直しに使えるよう応答に含まれるトークンを返す
+func (c *Client) confirmReservation(ctx context.Context, confirmsURL string, aspConfig *ASPConfig) (*ASPConfig, error) {
 	form := url.Values{}
-	form.Set("__VIEWSTATE", c.aspConfig.ViewState)
-	form.Set("__VIEWSTATEGENERATOR", c.aspConfig.ViewStateGenerator)
-	form.Set("__EVENTVALIDATION", c.aspConfig.EventValidation)
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
 	form.Set("KakuteiButton", "")
 
-	req, err = http.NewRequest(http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, confirmsURL, strings.NewReader(form.Encode()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err = c.DoRequest(req, true)
+	res, next, err := c.doRequest(req, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer res.Body.Close()
 
 	bodyBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !strings.Contains(string(bodyBytes), "予約が完了しました") {
-		return ErrCreateReservationFailed
+		return next, newServerError("reserve", ENDPOINT_CONFIRMS, bodyBytes, ErrCreateReservationFailed)
 	}
 
-	return nil
+	return next, nil
 }
 
 type CancelReservationParams struct {
@@ -245,6 +374,10 @@ type CancelReservationParams struct {
 }
 
 func (c *Client) CancelReservation(params *CancelReservationParams) error {
+	return c.CancelReservationContext(context.Background(), params)
+}
+
+func (c *Client) CancelReservationContext(ctx context.Context, params *CancelReservationParams) error {
 	if !IsIDValid(params.ReservationID) {
 		return ErrInvalidIDFormat
 	}
@@ -252,6 +385,12 @@ func (c *Client) CancelReservation(params *CancelReservationParams) error {
 		return ErrInvalidComment
 	}
 
+	return c.withRetry(ctx, c.withRelogin(func(ctx context.Context) error {
+		return c.cancelReservation(ctx, params)
+	}))
+}
+
+func (c *Client) cancelReservation(ctx context.Context, params *CancelReservationParams) error {
 	u, err := url.Parse(c.baseURL + ENDPOINT_CANCEL_RESERVATION)
 	if err != nil {
 		return err
@@ -261,12 +400,12 @@ func (c *Client) CancelReservation(params *CancelReservationParams) error {
 	q.Set("id", string(params.ReservationID))
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return err
 	}
 
-	res, err := c.DoRequest(req, true)
+	res, aspConfig, err := c.doRequest(req, true)
 	if err != nil {
 		return err
 	}
@@ -275,13 +414,13 @@ func (c *Client) CancelReservation(params *CancelReservationParams) error {
 	form := url.Values{}
 	form.Set("__EVENTTARGET", "")
 	form.Set("__EVENTARGUMENT", "")
-	form.Set("__VIEWSTATE", c.aspConfig.ViewState)
-	form.Set("__VIEWSTATEGENERATOR", c.aspConfig.ViewStateGenerator)
-	form.Set("__EVENTVALIDATION", c.aspConfig.EventValidation)
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
 	form.Set("freeword", params.Comment)
 	form.Set("YoyakuCancelButton", "")
 
-	req, err = http.NewRequest(http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return err
 	}
@@ -298,8 +437,209 @@ func (c *Client) CancelReservation(params *CancelReservationParams) error {
 	}
 
 	if !strings.Contains(string(bodyBytes), "予約キャンセル完了") {
-		return ErrCancelReservationFailed
+		return newServerError("cancel", ENDPOINT_CANCEL_RESERVATION, bodyBytes, ErrCancelReservationFailed)
+	}
+
+	return nil
+}
+
+type changeReservationParams struct {
+	ReservationID string
+	FromHour      int
+	FromMinute    int
+	ToHour        int
+	ToMinute      int
+}
+
+// 予約の利用時間を変更するフォームの項目
+var changeFormFields = []string{"fromh", "fromm", "toh", "tom"}
+
+const changeFormButton = "YoyakuHenkouButton"
+
+// 予約を取り消さずに利用時間を変更する。
+// 予約日は予約一覧から取得し、変更できたかどうかも予約一覧で確認する。
+// 変更ページに想定したフォームがない場合は、何も送信せずに ErrUnexpectedPage を返す。
+//
+// 実験的: change.aspx のフォーム（項目名と変更ボタン）は実サイトで確認できていないため、
+// 確認できるまでは公開しない
+func (c *Client) changeReservation(ctx context.Context, params *changeReservationParams) error {
+	if !IsIDValid(params.ReservationID) {
+		return ErrInvalidIDFormat
+	}
+	if !IsTimeRangeValid(params.FromHour, params.FromMinute, params.ToHour, params.ToMinute) {
+		return ErrInvalidTimeRange
+	}
+
+	return c.withRetry(ctx, c.withRelogin(func(ctx context.Context) error {
+		return c.submitChangeReservation(ctx, params)
+	}))
+}
+
+// 予約一覧から ID の予約を探す
+func (c *Client) findMyReservation(ctx context.Context, id string) (*Reservation, error) {
+	reservations, err := c.getMyReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reservations {
+		if reservations[i].ID == id {
+			return &reservations[i], nil
+		}
+	}
+	return nil, ErrReservationNotFound
+}
+
+func (p *changeReservationParams) matches(r *Reservation) bool {
+	return r.FromHour == p.FromHour && r.FromMinute == p.FromMinute && r.ToHour == p.ToHour && r.ToMinute == p.ToMinute
+}
+
+func (c *Client) submitChangeReservation(ctx context.Context, params *changeReservationParams) error {
+	reservation, err := c.findMyReservation(ctx, params.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	if !c.bookingPolicy.IsDateBookable(now, reservation.Date) {
+		return ErrDateOutOfRange
+	}
+	if !IsTimeInFutureAt(now, params.FromHour, params.FromMinute, reservation.Date) {
+		return ErrTimeInPast
+	}
+
+	// 再試行で同じ変更を送り直さない
+	if params.matches(reservation) {
+		return nil
+	}
+
+	u, err := url.Parse(c.baseURL + ENDPOINT_CHANGE_RESERVATION)
+	if err != nil {
+		return err
+	}
+
+	q := u.Query()
+	q.Set("id", params.ReservationID)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, aspConfig, err := c.doRequest(req, true)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	page, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	selects, buttons, err := parseFormFields(bytes.NewReader(page))
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	values := []string{
+		fmt.Sprintf("%02d", params.FromHour),
+		fmt.Sprintf("%02d", params.FromMinute),
+		fmt.Sprintf("%02d", params.ToHour),
+		fmt.Sprintf("%02d", params.ToMinute),
+	}
+	for i, name := range changeFormFields {
+		// 選択肢にない値は送らない
+		if !selects[name][values[i]] {
+			return fmt.Errorf("change form field %s=%s: %w", name, values[i], ErrUnexpectedPage)
+		}
+		form.Set(name, values[i])
+	}
+	if !buttons[changeFormButton] {
+		return fmt.Errorf("change form button %s: %w", changeFormButton, ErrUnexpectedPage)
+	}
+
+	form.Set("__EVENTTARGET", "")
+	form.Set("__EVENTARGUMENT", "")
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
+	form.Set(changeFormButton, "")
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err = c.DoRequest(req, true)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	// 完了ページの表示ではなく、予約一覧の利用時間で変更できたかを判断する
+	changed, err := c.findMyReservation(ctx, params.ReservationID)
+	if err != nil && !errors.Is(err, ErrReservationNotFound) {
+		return err
+	}
+	if changed == nil || !params.matches(changed) {
+		return newServerError("change", ENDPOINT_CHANGE_RESERVATION, bodyBytes, ErrChangeReservationFailed)
 	}
 
 	return nil
 }
+
+// フォームの select ごとの選択肢と submit ボタンの name を取り出す
+func parseFormFields(r io.Reader) (selects map[string]map[string]bool, buttons map[string]bool, err error) {
+	z := html.NewTokenizer(r)
+
+	selects = map[string]map[string]bool{}
+	buttons = map[string]bool{}
+	currentSelect := ""
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return selects, buttons, nil
+			}
+			return nil, nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+
+			switch t.Data {
+			case "select":
+				currentSelect = attrs["name"]
+				if currentSelect != "" {
+					selects[currentSelect] = map[string]bool{}
+				}
+			case "option":
+				if currentSelect != "" {
+					selects[currentSelect][attrs["value"]] = true
+				}
+			case "input":
+				if attrs["type"] == "submit" && attrs["name"] != "" {
+					buttons[attrs["name"]] = true
+				}
+			}
+
+		case html.EndTagToken:
+			if z.Token().Data == "select" {
+				currentSelect = ""
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package tcmrsv
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -49,6 +50,46 @@ func TestGetMyReservations(t *testing.T) {
 		if reservations[0].ID != "fa791156-cc27-f011-8c4e-000d3ace9c3e" {
 			t.Errorf("Expected ID to be fa791156-cc27-f011-8c4e-000d3ace9c3e, got %s", reservations[0].ID)
 		}
+
+		// 練習室一覧の部屋が紐付けられていることを確認
+		room, err := reservations[0].ResolvedRoom()
+		if err != nil {
+			t.Fatalf("Expected room to be resolved, got error: %v", err)
+		}
+		if room.ID != "b9f2e624-2f48-ec11-8c60-002248696fd6" || room.PianoType != RoomPianoTypeGrand || room.Floor != 4 {
+			t.Errorf("Unexpected room: %+v", room)
+		}
+	})
+
+	t.Run("UnknownRoom", func(t *testing.T) {
+		html := strings.Replace(LoadFixture("personal/facility/index.html"), "<span>A414（G）</span>", "<span>A999（G）</span>", 1)
+
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(html))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		reservations, err := mockServer.Client.GetMyReservations()
+		if err != nil {
+			t.Fatalf("Expected successful retrieval, got error: %v", err)
+		}
+
+		// 一覧にない部屋があっても取得自体は成功する
+		if reservations[0].Room != nil {
+			t.Errorf("Expected unknown room not to be resolved, got %+v", reservations[0].Room)
+		}
+		_, err = reservations[0].ResolvedRoom()
+		if !errors.Is(err, ErrRoomNotFound) || !strings.Contains(err.Error(), "A999（G）") {
+			t.Errorf("Expected room not found error with the room name, got: %v", err)
+		}
+
+		if reservations[1].Room == nil || reservations[1].Room.Name != "A415（G）" {
+			t.Errorf("Expected A415（G） to be resolved, got %+v", reservations[1].Room)
+		}
 	})
 
 	t.Run("EmptyReservations", func(t *testing.T) {
@@ -134,6 +175,8 @@ func TestGetMyReservations(t *testing.T) {
 
 func TestReserve(t *testing.T) {
 	t.Run("SuccessfulReservation", func(t *testing.T) {
+		tomorrow := Today().AddDays(1)
+
 		routes := map[string]http.HandlerFunc{
 			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
 				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
@@ -141,17 +184,99 @@ func TestReserve(t *testing.T) {
 			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
 				w.Write([]byte(LoadFixture("personal/facility/done.html")))
 			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(reservationListFor(tomorrow)))
+			},
 		}
 
 		mockServer := NewMockServer(CreateHandler(routes))
 		defer mockServer.Close()
 
+		reservation, err := mockServer.Client.Reserve(&ReserveParams{
+			Campus:     CampusIkebukuro,
+			RoomID:     "b9f2e624-2f48-ec11-8c60-002248696fd6", // A414（G）
+			Date:       tomorrow,
+			FromHour:   17,
+			FromMinute: 0,
+			ToHour:     22,
+			ToMinute:   30,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if reservation.ID != "fa791156-cc27-f011-8c4e-000d3ace9c3e" {
+			t.Errorf("Expected ID to be fa791156-cc27-f011-8c4e-000d3ace9c3e, got %s", reservation.ID)
+		}
+
+		if reservation.RoomName != "A414（G）" {
+			t.Errorf("Expected room name to be A414（G）, got %s", reservation.RoomName)
+		}
+	})
+
+	t.Run("AmbiguousReservation", func(t *testing.T) {
 		tomorrow := Today().AddDays(1)
 
-		err := mockServer.Client.Reserve(&ReserveParams{
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(reservationListFor(tomorrow)))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		// カタログにない部屋は部屋名で絞り込めないため、同じ時間帯の予約が複数一致する
+		reservation, err := mockServer.Client.Reserve(&ReserveParams{
+			Campus:     CampusIkebukuro,
+			RoomID:     "00000000-0000-0000-0000-000000000000",
+			Date:       tomorrow,
+			FromHour:   17,
+			FromMinute: 0,
+			ToHour:     22,
+			ToMinute:   30,
+		})
+
+		// 予約自体は完了しているためエラーにはしない
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if !reservation.Unresolved || reservation.ID != "" {
+			t.Errorf("Expected unresolved reservation without ID, got %+v", reservation)
+		}
+		if reservation.ResolveErr != ErrAmbiguousReservation {
+			t.Errorf("Expected ambiguous reservation error, got: %v", reservation.ResolveErr)
+		}
+	})
+
+	t.Run("ReservationNotFound", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		reservation, err := mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6", // P 200（G）
-			Date:       tomorrow,
+			Date:       Today().AddDays(1),
 			FromHour:   10,
 			FromMinute: 30,
 			ToHour:     11,
@@ -159,7 +284,63 @@ func TestReserve(t *testing.T) {
 		})
 
 		if err != nil {
-			t.Errorf("Expected successful reservation, got error: %v", err)
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if !reservation.Unresolved || reservation.ID != "" || reservation.RoomName != "P 200（G）" {
+			t.Errorf("Expected unresolved reservation filled from params, got %+v", reservation)
+		}
+		if reservation.ResolveErr != ErrReservationNotFound {
+			t.Errorf("Expected reservation not found error, got: %v", reservation.ResolveErr)
+		}
+	})
+
+	t.Run("ReservationListUnavailable", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("errorpage.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		defer mockServer.Close()
+
+		// 予約一覧を取得できなくても、予約は完了しているためエラーにはしない
+		reservation, err := mockServer.Client.Reserve(&ReserveParams{
+			Campus:     CampusNakameguro,
+			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
+			Date:       Today().AddDays(1),
+			FromHour:   10,
+			FromMinute: 30,
+			ToHour:     11,
+			ToMinute:   30,
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if !reservation.Unresolved || reservation.ID != "" {
+			t.Errorf("Expected unresolved reservation without ID, got %+v", reservation)
+		}
+		if !errors.Is(reservation.ResolveErr, ErrInternalServer) {
+			t.Errorf("Expected internal server error, got: %v", reservation.ResolveErr)
+		}
+
+		posts := 0
+		for _, req := range mockServer.Requests {
+			if req.Method == http.MethodPost {
+				posts++
+			}
+		}
+		if posts != 1 {
+			t.Errorf("Expected reservation to be submitted once, got %d", posts)
 		}
 	})
 
@@ -171,7 +352,7 @@ func TestReserve(t *testing.T) {
 		defer mockServer.Close()
 
 		// 無効なキャンパスのテスト
-		err := mockServer.Client.Reserve(&ReserveParams{
+		_, err := mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusUnknown,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
 			Date:       Today().AddDays(1),
@@ -186,7 +367,7 @@ func TestReserve(t *testing.T) {
 		}
 
 		// 無効な部屋IDのテスト
-		err = mockServer.Client.Reserve(&ReserveParams{
+		_, err = mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "invalid-id",
 			Date:       Today().AddDays(1),
@@ -202,7 +383,7 @@ func TestReserve(t *testing.T) {
 
 		// 範囲外の日付のテスト
 		futureDate := Today().AddDays(4)
-		err = mockServer.Client.Reserve(&ReserveParams{
+		_, err = mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
 			Date:       futureDate,
@@ -217,7 +398,7 @@ func TestReserve(t *testing.T) {
 		}
 
 		// 無効な時間範囲のテスト
-		err = mockServer.Client.Reserve(&ReserveParams{
+		_, err = mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
 			Date:       Today().AddDays(1),
@@ -247,7 +428,7 @@ func TestReserve(t *testing.T) {
 
 		tomorrow := Today().AddDays(1)
 
-		err := mockServer.Client.Reserve(&ReserveParams{
+		_, err := mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
 			Date:       tomorrow,
@@ -257,9 +438,27 @@ func TestReserve(t *testing.T) {
 			ToMinute:   30,
 		})
 
-		if err != ErrCreateReservationFailed {
+		if !errors.Is(err, ErrCreateReservationFailed) {
 			t.Errorf("Expected reservation failure, got: %v", err)
 		}
+
+		// サーバーのメッセージから失敗理由を判別できる
+		if !errors.Is(err, ErrSlotTaken) {
+			t.Errorf("Expected slot taken error, got: %v", err)
+		}
+
+		var serverErr *ServerError
+		if !errors.As(err, &serverErr) {
+			t.Fatalf("Expected *ServerError, got: %T", err)
+		}
+
+		if serverErr.Message != "指定した時間は予約できません。" {
+			t.Errorf("Expected message to be 指定した時間は予約できません。, got %q", serverErr.Message)
+		}
+
+		if serverErr.Page != ENDPOINT_CONFIRMS {
+			t.Errorf("Expected page to be %s, got %s", ENDPOINT_CONFIRMS, serverErr.Page)
+		}
 	})
 
 	t.Run("ServerError", func(t *testing.T) {
@@ -276,7 +475,7 @@ func TestReserve(t *testing.T) {
 
 		tomorrow := Today().AddDays(1)
 
-		err := mockServer.Client.Reserve(&ReserveParams{
+		_, err := mockServer.Client.Reserve(&ReserveParams{
 			Campus:     CampusNakameguro,
 			RoomID:     "23f2e624-2f48-ec11-8c60-002248696fd6",
 			Date:       tomorrow,
@@ -291,3 +490,12 @@ func TestReserve(t *testing.T) {
 		}
 	})
 }
+
+// 予約一覧ページの予約日を date に置き換える
+func reservationListFor(date Date) string {
+	return strings.ReplaceAll(
+		LoadFixture("personal/facility/index.html"),
+		"2025年05月05日",
+		date.ToTime().Format("2006年01月02日"),
+	)
+}
@@ -0,0 +1,84 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// サーバー混雑ページ（ErrInternalServer）を受け取ったときの再試行方針
+type RetryPolicy struct {
+	// 最初の試行を含む最大試行回数。1 以下なら再試行しない
+	MaxAttempts int
+	// 1 回目の再試行までの待機時間
+	InitialBackoff time.Duration
+	// 待機時間の上限
+	MaxBackoff time.Duration
+	// 再試行ごとに待機時間へ掛ける倍率。1 未満なら 1（待機時間を一定にする）
+	Multiplier float64
+	// 待機時間をランダムに短縮する割合（0〜1）
+	Jitter float64
+	// 全試行を含めた 1 操作あたりの制限時間。0 なら無制限
+	OperationTimeout time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:      5,
+		InitialBackoff:   200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		Multiplier:       2,
+		Jitter:           0.5,
+		OperationTimeout: 30 * time.Second,
+	}
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	// 倍率が 0 のままだと 2 回目以降の待機時間が 0 になり、混雑しているサーバーに連続で送信してしまう
+	multiplier := max(p.Multiplier, 1)
+
+	d := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		d *= multiplier
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// fn を再試行方針に従って実行する。
+// フォームを送信する操作は GET からやり直すため、毎回新しい __VIEWSTATE / __EVENTVALIDATION が使われる。
+// 待機中に ctx や OperationTimeout が終了した場合は ctx.Err() を返す
+func (c *Client) withRetry(ctx context.Context, fn func(ctx context.Context) error) error {
+	policy := c.retryPolicy
+
+	if policy.OperationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.OperationTimeout)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !errors.Is(err, ErrInternalServer) || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last attempt: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
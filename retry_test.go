@@ -0,0 +1,177 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:      3,
+		InitialBackoff:   time.Millisecond,
+		MaxBackoff:       5 * time.Millisecond,
+		Multiplier:       2,
+		OperationTimeout: time.Second,
+	}
+
+	t.Run("RetriesFormFlowWithFreshTokens", func(t *testing.T) {
+		posts := 0
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/cancel.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/cancel.html")))
+			},
+			"POST /personal/facility/cancel.aspx": func(w http.ResponseWriter, r *http.Request) {
+				posts++
+				if posts == 1 {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(LoadFixture("errorpage.html")))
+					return
+				}
+				w.Write([]byte(LoadFixture("personal/facility/cancel_done.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(policy))
+		defer mockServer.Close()
+
+		err := mockServer.Client.CancelReservation(&CancelReservationParams{
+			ReservationID: "59253854-3628-f011-8c4e-000d3a51476f",
+			Comment:       "テストのためキャンセル",
+		})
+
+		if err != nil {
+			t.Errorf("Expected successful cancellation after retry, got error: %v", err)
+		}
+
+		// GET → POST（混雑）→ GET → POST の順でリクエストされる
+		methods := []string{http.MethodGet, http.MethodPost, http.MethodGet, http.MethodPost}
+		if len(mockServer.Requests) != len(methods) {
+			t.Fatalf("Expected %d requests, got %d", len(methods), len(mockServer.Requests))
+		}
+		for i, m := range methods {
+			if mockServer.Requests[i].Method != m {
+				t.Errorf("Expected request %d to be %s, got %s", i, m, mockServer.Requests[i].Method)
+			}
+		}
+
+		// 再送時のフォームにも最新のトークンが含まれる
+		body, _ := io.ReadAll(mockServer.Requests[3].Body)
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			t.Fatalf("Failed to parse form: %v", err)
+		}
+		if form.Get("__EVENTVALIDATION") == "" {
+			t.Error("Expected __EVENTVALIDATION to be set on retried POST")
+		}
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(LoadFixture("errorpage.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(policy))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetMyReservations()
+
+		if err != ErrInternalServer {
+			t.Errorf("Expected internal server error, got: %v", err)
+		}
+
+		if len(mockServer.Requests) != policy.MaxAttempts {
+			t.Errorf("Expected %d requests, got %d", policy.MaxAttempts, len(mockServer.Requests))
+		}
+	})
+
+	t.Run("TimesOutDuringBackoff", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(LoadFixture("errorpage.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(RetryPolicy{
+			MaxAttempts:      3,
+			InitialBackoff:   time.Second,
+			OperationTimeout: 10 * time.Millisecond,
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetMyReservations()
+
+		// 混雑ではなく制限時間で終了したことが分かる
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrInternalServer) {
+			t.Errorf("Expected deadline exceeded, got: %v", err)
+		}
+	})
+
+	t.Run("DoesNotRetryOtherErrors", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("index.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(policy))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetMyReservations()
+
+		if err != ErrAuthenticationFailed {
+			t.Errorf("Expected authentication failure, got: %v", err)
+		}
+
+		if len(mockServer.Requests) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(mockServer.Requests))
+		}
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		p := RetryPolicy{
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			Multiplier:     2,
+		}
+
+		tests := []struct {
+			retry int
+			want  time.Duration
+		}{
+			{1, 100 * time.Millisecond},
+			{2, 200 * time.Millisecond},
+			{3, 400 * time.Millisecond},
+			{5, time.Second},
+		}
+
+		for _, tt := range tests {
+			if got := p.backoff(tt.retry); got != tt.want {
+				t.Errorf("backoff(%d) = %v; want %v", tt.retry, got, tt.want)
+			}
+		}
+
+		// 倍率を指定しなければ待機時間は一定
+		constant := RetryPolicy{InitialBackoff: time.Second}
+		for retry := 1; retry <= 4; retry++ {
+			if got := constant.backoff(retry); got != time.Second {
+				t.Errorf("backoff(%d) without multiplier = %v; want 1s", retry, got)
+			}
+		}
+
+		p.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			if got := p.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+				t.Errorf("backoff(1) with jitter = %v; want between 50ms and 100ms", got)
+			}
+		}
+	})
+}
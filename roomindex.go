@@ -0,0 +1,116 @@
+package tcmrsv
+
+import (
+	"slices"
+	"sync"
+)
+
+// 練習室一覧から作る検索用の索引
+// 作成後は変更しないため、複数の goroutine から同時に参照できる
+type roomIndex struct {
+	rooms       []Room
+	byID        map[string]int
+	byName      map[string][]int
+	byCampus    map[Campus][]int
+	byFloor     map[int][]int
+	byPianoType map[RoomPianoType][]int
+}
+
+func newRoomIndex(rooms []Room) *roomIndex {
+	idx := &roomIndex{
+		rooms:       make([]Room, len(rooms)),
+		byID:        make(map[string]int, len(rooms)),
+		byName:      make(map[string][]int, len(rooms)),
+		byCampus:    map[Campus][]int{},
+		byFloor:     map[int][]int{},
+		byPianoType: map[RoomPianoType][]int{},
+	}
+	copy(idx.rooms, rooms)
+
+	for i, r := range idx.rooms {
+		if r.ID != "" {
+			if _, ok := idx.byID[r.ID]; !ok {
+				idx.byID[r.ID] = i
+			}
+		}
+		name := NormalizeRoomName(r.Name)
+		idx.byName[name] = append(idx.byName[name], i)
+		idx.byCampus[r.Campus] = append(idx.byCampus[r.Campus], i)
+		idx.byFloor[r.Floor] = append(idx.byFloor[r.Floor], i)
+		idx.byPianoType[r.PianoType] = append(idx.byPianoType[r.PianoType], i)
+	}
+
+	return idx
+}
+
+var defaultRoomIndex = sync.OnceValue(func() *roomIndex {
+	return newRoomIndex(defaultRoomCatalog().Rooms)
+})
+
+func (idx *roomIndex) findByID(id string) (Room, bool) {
+	i, ok := idx.byID[id]
+	if !ok {
+		return Room{}, false
+	}
+	return idx.rooms[i], true
+}
+
+// 部屋名は NormalizeRoomName で正規化して比較する。campus が無効な場合はすべてのキャンパスから探す
+func (idx *roomIndex) findByName(campus Campus, name string) (Room, bool) {
+	for _, i := range idx.byName[NormalizeRoomName(name)] {
+		if campus.IsValid() && idx.rooms[i].Campus != campus {
+			continue
+		}
+		return idx.rooms[i], true
+	}
+	return Room{}, false
+}
+
+// 条件に一致しうる部屋の位置を返す
+// キャンパス・階・ピアノの種類のうち、最も絞り込める索引を使う
+func (idx *roomIndex) candidates(params *GetRoomsFilteredParams) []int {
+	var best []int
+	narrowed := false
+
+	narrow := func(positions []int) {
+		if !narrowed || len(positions) < len(best) {
+			best = positions
+			narrowed = true
+		}
+	}
+
+	if params.ID != nil {
+		if i, ok := idx.byID[*params.ID]; ok {
+			narrow([]int{i})
+		} else {
+			narrow(nil)
+		}
+	}
+	if len(params.Campuses) > 0 {
+		narrow(lookupAll(idx.byCampus, params.Campuses))
+	}
+	if len(params.Floors) > 0 {
+		narrow(lookupAll(idx.byFloor, params.Floors))
+	}
+	if len(params.PianoTypes) > 0 {
+		narrow(lookupAll(idx.byPianoType, params.PianoTypes))
+	}
+
+	if !narrowed {
+		best = make([]int, len(idx.rooms))
+		for i := range best {
+			best[i] = i
+		}
+	}
+	return best
+}
+
+// keys のいずれかに一致する部屋の位置を、一覧の順に重複なく返す
+func lookupAll[K comparable](m map[K][]int, keys []K) []int {
+	var positions []int
+	for _, k := range keys {
+		positions = append(positions, m[k]...)
+	}
+	slices.Sort(positions)
+	return slices.Compact(positions)
+}
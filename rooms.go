@@ -1,8 +1,10 @@
 package tcmrsv
 
 import (
+	"cmp"
 	"slices"
 	"strings"
+	"unicode"
 )
 
 type GetRoomsFilteredParams struct {
@@ -12,12 +14,70 @@ type GetRoomsFilteredParams struct {
 	PianoTypes   []RoomPianoType
 	Floors       []int
 	IsBasement   *bool
+	IsClassroom  *bool
 	Campuses     []Campus
+
+	// 除外する部屋
+	ExcludeIDs    []string
+	ExcludeFloors []int
+
+	// 並び順（先に指定したものを優先する）。指定しなければ練習室一覧の順
+	SortBy         []RoomSortKey
+	SortDescending bool
+
+	// 並べ替えた後に Offset 件を飛ばして最大 Limit 件を返す（0 なら制限しない）
+	Limit  int
+	Offset int
+}
+
+type RoomSortKey string
+
+const (
+	RoomSortKeyCampus RoomSortKey = "campus"
+	// 地下の階は地上の階より前になる
+	RoomSortKeyFloor RoomSortKey = "floor"
+	RoomSortKeyName  RoomSortKey = "name"
+	// ピアノの種類（グランド、アップライト、なし、不明の順）と台数
+	RoomSortKeyPiano RoomSortKey = "piano"
+)
+
+var pianoTypeOrder = map[RoomPianoType]int{
+	RoomPianoTypeGrand:   0,
+	RoomPianoTypeUpright: 1,
+	RoomPianoTypeNone:    2,
+	RoomPianoTypeUnknown: 3,
+}
+
+func compareRooms(a, b Room, key RoomSortKey) int {
+	switch key {
+	case RoomSortKeyCampus:
+		return strings.Compare(string(a.Campus), string(b.Campus))
+	case RoomSortKeyFloor:
+		floor := func(r Room) int {
+			if r.IsBasement {
+				return -r.Floor
+			}
+			return r.Floor
+		}
+		return cmp.Compare(floor(a), floor(b))
+	case RoomSortKeyName:
+		return strings.Compare(NormalizeRoomName(a.Name), NormalizeRoomName(b.Name))
+	case RoomSortKeyPiano:
+		if c := cmp.Compare(pianoTypeOrder[a.PianoType], pianoTypeOrder[b.PianoType]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PianoNumber, b.PianoNumber)
+	default:
+		return 0
+	}
 }
 
 func (c *Client) GetRoomsFiltered(params GetRoomsFilteredParams) []Room {
+	index := c.index()
+
 	var result []Room
-	for _, room := range c.GetRooms() {
+	for _, i := range index.candidates(&params) {
+		room := index.rooms[i]
 		if params.Name != nil && !strings.Contains(strings.ToLower(room.Name), strings.ToLower(*params.Name)) {
 			continue
 		}
@@ -45,6 +105,12 @@ func (c *Client) GetRoomsFiltered(params GetRoomsFilteredParams) []Room {
 		if params.IsBasement != nil && room.IsBasement != *params.IsBasement {
 			continue
 		}
+		if params.IsClassroom != nil && room.IsClassroom != *params.IsClassroom {
+			continue
+		}
+		if slices.Contains(params.ExcludeIDs, room.ID) || slices.Contains(params.ExcludeFloors, room.Floor) {
+			continue
+		}
 		if len(params.Campuses) > 0 {
 			matched := slices.Contains(params.Campuses, room.Campus)
 			if !matched {
@@ -53,1327 +119,81 @@ func (c *Client) GetRoomsFiltered(params GetRoomsFilteredParams) []Room {
 		}
 		result = append(result, room)
 	}
+
+	if len(params.SortBy) > 0 {
+		slices.SortStableFunc(result, func(a, b Room) int {
+			for _, key := range params.SortBy {
+				c := compareRooms(a, b, key)
+				if params.SortDescending {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+
+	if params.Offset > 0 {
+		if params.Offset >= len(result) {
+			return nil
+		}
+		result = result[params.Offset:]
+	}
+	if params.Limit > 0 && params.Limit < len(result) {
+		result = result[:params.Limit]
+	}
+
 	return result
 }
 
+// 練習室一覧を返す
+// WithRoomCatalog が指定されていなければ、パッケージに埋め込まれた一覧を使う
 func (c *Client) GetRooms() []Room {
-	return []Room{
-		// 中目黒・代官山 2F
-		{
-			ID:          "9ed14a61-a3e2-ef11-be20-7c1e52246dd3",
-			Name:        "楽屋2（U）旧楽屋201",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "29f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "楽屋3（G）旧楽屋202",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "23f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 200（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "27f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 201（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "2bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 202（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "2df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 203（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "2ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 204（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "31f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 205（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "37f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 208（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "39f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 209（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "3bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 210（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "3df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 211（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "3ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 212（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "41f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 213（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "43f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 214（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "45f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 215（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "47f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 216（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "49f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 217（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "4bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 218（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "4df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 219（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "4ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 220（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "51f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 221（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "53f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 222（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "55f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 223（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "57f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 224（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "59f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 225（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "5bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 226（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		{
-			ID:          "5df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 227（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       2,
-		},
-		// 中目黒・代官山 4F
-		{
-			ID:          "69f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 426（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "6bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 427（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "6df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 428（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "6ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 429（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "71f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 430（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "73f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 431（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "75f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 432（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "77f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 433（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "79f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 434（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "7bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 435（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "7df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 436（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "7ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 437（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "81f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 438（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "83f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 439（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "85f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 440（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "87f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 441（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "89f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 442（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "8bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 443（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "8df2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 445（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "8ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 450（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "91f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 451（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "93f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 452（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "95f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 453（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "97f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 454（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "99f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 455（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "9bf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 456（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		// {
-		// 	ID:          "", // TODO: find ID
-		// 	Name:        "P 457（G）",
-		// 	PianoType:   RoomPianoTypeGrand,
-		// 	PianoNumber: 1,
-		// 	IsClassroom: false,
-		// 	IsBasement:  false,
-		// 	Campus:      CampusNakameguro,
-		// 	Floor:       4,
-		// },
-		{
-			ID:          "9ff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 458（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "a1f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 459（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "a3f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "P 460（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		// 中目黒・代官山 教室（休日のみ）
-		// {
-		// 	ID:          "", // TODO: find ID
-		// 	Name:        "C300",
-		// 	PianoType:   RoomPianoTypeGrand,
-		// 	PianoNumber: 1,
-		// 	IsClassroom: true,
-		// 	IsBasement:  false,
-		// 	Campus:      CampusNakameguro,
-		// 	Floor:       3,
-		// },
-		// {
-		// 	ID:          "", // TODO: find ID
-		// 	Name:        "C302",
-		// 	PianoType:   RoomPianoTypeGrand,
-		// 	PianoNumber: 1,
-		// 	IsClassroom: true,
-		// 	IsBasement:  false,
-		// 	Campus:      CampusNakameguro,
-		// 	Floor:       3,
-		// },
-		// {
-		// 	ID:          "", // TODO: find ID
-		// 	Name:        "C303",
-		// 	PianoType:   RoomPianoTypeGrand,
-		// 	PianoNumber: 1,
-		// 	IsClassroom: true,
-		// 	IsBasement:  false,
-		// 	Campus:      CampusNakameguro,
-		// 	Floor:       3,
-		// },
-		{
-			ID:          "0d89ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C304",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "0f89ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C305",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "1189ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C306",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "1389ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C307",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "1589ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C308",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "1789ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C309",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       3,
-		},
-		{
-			ID:          "1989ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C400",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "5a0de9dd-61b6-ed11-b597-00224868d035",
-			Name:        "C401",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "1b89ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C403",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "1d89ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C404",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "1f89ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C405",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "2189ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C406",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "2389ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C407",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "2589ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C408",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "2789ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C409",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		{
-			ID:          "2989ec71-7523-ed11-9db1-000d3a506b12",
-			Name:        "C410",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusNakameguro,
-			Floor:       4,
-		},
-		// 池袋 地下
-		{
-			ID:          "dff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下103（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "0bf3e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下104（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "09f3e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下105（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "07f3e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下106（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "e9f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下108（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "ebf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下109（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "edf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下110（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "eff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下111（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "f1f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下112（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "f3f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下113（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "f5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下114（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "f7f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下115（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "f9f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下116（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "fbf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下117（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "fdf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下118（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "fff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下119（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "01f3e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下120（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "e1f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下123（無）",
-			PianoType:   RoomPianoTypeNone,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "05f3e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下124（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "e3f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下125（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		{
-			ID:          "e5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A地下126（無）",
-			PianoType:   RoomPianoTypeNone,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  true,
-			Campus:      CampusIkebukuro,
-			Floor:       1,
-		},
-		// 池袋 4階
-		{
-			ID:          "a7f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A405（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "a9f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A406（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "b5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A412（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "b7f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A413（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "b9f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A414（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "bbf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A415（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "bdf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A416（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "bff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A417（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "a5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A418（無）",
-			PianoType:   RoomPianoTypeNone,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "c1f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A419（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "c3f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A420（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "c5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A421（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "c7f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A422（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "c9f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A423（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "cbf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A424（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "cdf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A425（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "cff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A426（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "d1f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A427（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "d3f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A428（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "d5f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A429（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "d7f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A430（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "0ab0184d-254e-ef11-a317-6045bd67f236",
-			Name:        "A431（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "dbf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A432（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "ddf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A433（U）",
-			PianoType:   RoomPianoTypeUpright,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "65f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A401（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "abf2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A407（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "67f2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A402（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		{
-			ID:          "aff2e624-2f48-ec11-8c60-002248696fd6",
-			Name:        "A409（G2台）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 2,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       4,
-		},
-		// 池袋 B5F
-		{
-			ID:          "29e9e220-301c-f011-998a-000d3ace9c3e",
-			Name:        "B503（G）",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: false,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       5,
-		},
-		// 池袋 教室（休日のみ）
-		{
-			ID:          "3b22f830-7523-ed11-9db1-000d3a506b12",
-			Name:        "A301",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       3,
-		},
-		{
-			ID:          "3d22f830-7523-ed11-9db1-000d3a506b12",
-			Name:        "A302",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       3,
-		},
-		{
-			ID:          "3f22f830-7523-ed11-9db1-000d3a506b12",
-			Name:        "A303",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       3,
-		},
-		{
-			ID:          "4122f830-7523-ed11-9db1-000d3a506b12",
-			Name:        "A304",
-			PianoType:   RoomPianoTypeGrand,
-			PianoNumber: 1,
-			IsClassroom: true,
-			IsBasement:  false,
-			Campus:      CampusIkebukuro,
-			Floor:       3,
-		},
+	rooms := c.index().rooms
+	result := make([]Room, len(rooms))
+	copy(result, rooms)
+	return result
+}
+
+func (c *Client) GetRoomByID(id string) (Room, bool) {
+	return c.index().findByID(id)
+}
+
+// 部屋名は NormalizeRoomName で正規化して比較する
+func (c *Client) GetRoomByName(name string) (Room, bool) {
+	return c.index().findByName(CampusUnknown, name)
+}
+
+func (c *Client) RoomCatalog() *RoomCatalog {
+	if c.rooms != nil {
+		return c.rooms.Clone()
+	}
+	return DefaultRoomCatalog()
+}
+
+func (c *Client) index() *roomIndex {
+	if c.roomIndex != nil {
+		return c.roomIndex
+	}
+	return defaultRoomIndex()
+}
+
+// 部屋名を比較用に正規化する
+// 全角英数字・括弧を半角にし、空白を取り除く（例: "P 200（G）" と "P200(G)" は同じ名前になる）
+func NormalizeRoomName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r >= '！' && r <= '～':
+			// 全角の ASCII 文字は 0xFEE0 ずらすと半角になる
+			b.WriteRune(r - 0xFEE0)
+		default:
+			b.WriteRune(r)
+		}
 	}
+	return b.String()
 }
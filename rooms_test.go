@@ -3,6 +3,7 @@ package tcmrsv
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetRooms(t *testing.T) {
@@ -183,4 +184,224 @@ func TestGetRoomsFiltered(t *testing.T) {
 			t.Errorf("Expected no rooms with ID %s, got %d", nonExistentID, len(result))
 		}
 	})
+
+	t.Run("FilterByIsClassroom", func(t *testing.T) {
+		isClassroom := true
+		result := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			IsClassroom: &isClassroom,
+		})
+
+		if len(result) == 0 {
+			t.Error("Expected classrooms, got none")
+		}
+
+		for _, room := range result {
+			if !room.IsClassroom {
+				t.Errorf("Expected classroom, got %s", room.Name)
+			}
+		}
+	})
+
+	t.Run("ExcludeFilters", func(t *testing.T) {
+		excludedID := "9ed14a61-a3e2-ef11-be20-7c1e52246dd3"
+		result := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			Campuses:      []Campus{CampusNakameguro},
+			ExcludeIDs:    []string{excludedID},
+			ExcludeFloors: []int{4},
+		})
+
+		if len(result) == 0 {
+			t.Error("Expected rooms after exclusion, got none")
+		}
+
+		for _, room := range result {
+			if room.ID == excludedID {
+				t.Errorf("Expected room %s to be excluded", excludedID)
+			}
+			if room.Floor == 4 {
+				t.Errorf("Expected rooms on floor 4 to be excluded, got %s", room.Name)
+			}
+		}
+	})
+
+	t.Run("SortAndPaginate", func(t *testing.T) {
+		all := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			Campuses: []Campus{CampusIkebukuro},
+			SortBy:   []RoomSortKey{RoomSortKeyFloor, RoomSortKeyName},
+		})
+
+		if len(all) < 10 {
+			t.Fatalf("Expected at least 10 rooms in Ikebukuro campus, got %d", len(all))
+		}
+
+		// 地下の部屋が先に並ぶ
+		if !all[0].IsBasement {
+			t.Errorf("Expected basement room first, got %s", all[0].Name)
+		}
+		for i := 1; i < len(all); i++ {
+			if c := compareRooms(all[i-1], all[i], RoomSortKeyFloor); c > 0 {
+				t.Errorf("Expected %s before %s", all[i].Name, all[i-1].Name)
+			} else if c == 0 && compareRooms(all[i-1], all[i], RoomSortKeyName) > 0 {
+				t.Errorf("Expected %s before %s on the same floor", all[i].Name, all[i-1].Name)
+			}
+		}
+
+		page := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			Campuses: []Campus{CampusIkebukuro},
+			SortBy:   []RoomSortKey{RoomSortKeyFloor, RoomSortKeyName},
+			Offset:   5,
+			Limit:    3,
+		})
+
+		if len(page) != 3 {
+			t.Fatalf("Expected 3 rooms, got %d", len(page))
+		}
+		for i, room := range page {
+			if room.ID != all[5+i].ID {
+				t.Errorf("Expected %s at position %d, got %s", all[5+i].Name, i, room.Name)
+			}
+		}
+
+		desc := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			Campuses:       []Campus{CampusIkebukuro},
+			SortBy:         []RoomSortKey{RoomSortKeyPiano},
+			SortDescending: true,
+		})
+		if desc[0].PianoType == RoomPianoTypeGrand {
+			t.Errorf("Expected grand pianos last in descending order, got %s first", desc[0].Name)
+		}
+
+		beyond := client.GetRoomsFiltered(GetRoomsFilteredParams{
+			Campuses: []Campus{CampusIkebukuro},
+			Offset:   len(all),
+		})
+		if len(beyond) != 0 {
+			t.Errorf("Expected no rooms beyond the last page, got %d", len(beyond))
+		}
+	})
+}
+
+func TestNormalizeRoomName(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"A414（G）", "A414(G)"},
+		{"P 200（G）", "P200（G）"},
+		{"P 200（G）", "P　200(G)"},
+		{"Ａ４１４（Ｇ）", "A414（G）"},
+		{"楽屋2（U）旧楽屋201", "楽屋2 (U) 旧楽屋201"},
+	}
+
+	for _, tt := range tests {
+		if NormalizeRoomName(tt.a) != NormalizeRoomName(tt.b) {
+			t.Errorf("Expected %q and %q to be normalized to the same name, got %q and %q", tt.a, tt.b, NormalizeRoomName(tt.a), NormalizeRoomName(tt.b))
+		}
+	}
+
+	if NormalizeRoomName("A414（G）") == NormalizeRoomName("A415（G）") {
+		t.Error("Expected different rooms to stay different")
+	}
+
+	room, ok := DefaultRoomCatalog().FindByName("P200(G)")
+	if !ok || room.ID != "23f2e624-2f48-ec11-8c60-002248696fd6" {
+		t.Errorf("Expected P 200（G） to be found by a half-width name, got %+v", room)
+	}
+}
+
+func TestGetRoomByIDAndName(t *testing.T) {
+	client := New()
+
+	room, ok := client.GetRoomByID("b9f2e624-2f48-ec11-8c60-002248696fd6")
+	if !ok || room.Name != "A414（G）" {
+		t.Errorf("Expected A414（G） to be found by ID, got %+v", room)
+	}
+
+	room, ok = client.GetRoomByName("P200(G)")
+	if !ok || room.ID != "23f2e624-2f48-ec11-8c60-002248696fd6" {
+		t.Errorf("Expected P 200（G） to be found by name, got %+v", room)
+	}
+
+	if _, ok := client.GetRoomByID("00000000-0000-0000-0000-000000000000"); ok {
+		t.Error("Expected unknown ID not to be found")
+	}
+	if _, ok := client.GetRoomByName("A999（G）"); ok {
+		t.Error("Expected unknown name not to be found")
+	}
+
+	// 返された一覧を書き換えても索引には影響しない
+	rooms := client.GetRooms()
+	rooms[0].Name = "changed"
+	if client.GetRooms()[0].Name == "changed" {
+		t.Error("Expected rooms to be copied")
+	}
+}
+
+func BenchmarkGetRoomByName(b *testing.B) {
+	client := New()
+	rooms := client.GetRooms()
+	name := rooms[len(rooms)-1].Name
+
+	b.Run("Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			client.GetRoomByName(name)
+		}
+	})
+
+	// 索引を使わずに一覧を走査する場合
+	b.Run("LinearScan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, r := range client.GetRooms() {
+				if NormalizeRoomName(r.Name) == NormalizeRoomName(name) {
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkGetRoomByID(b *testing.B) {
+	client := New()
+	rooms := client.GetRooms()
+	id := rooms[len(rooms)-1].ID
+
+	b.Run("Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			client.GetRoomByID(id)
+		}
+	})
+
+	b.Run("LinearScan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, r := range client.GetRooms() {
+				if r.ID == id {
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkGetRoomsFiltered(b *testing.B) {
+	client := New()
+	params := GetRoomsFilteredParams{
+		Campuses: []Campus{CampusIkebukuro},
+		Floors:   []int{4},
+	}
+
+	for i := 0; i < b.N; i++ {
+		client.GetRoomsFiltered(params)
+	}
+}
+
+func BenchmarkParseRoomSchedule(b *testing.B) {
+	client := New()
+	page := LoadFixture("personal/facility/reserve_with_inputs.html")
+	date := Today().AddDays(1)
+	now := time.Now().In(jst)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parseRoomSchedule(strings.NewReader(page), client.index(), CampusNakameguro, date, now); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,254 @@
+package tcmrsv
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+type GetRoomScheduleParams struct {
+	Campus Campus
+	Date   Date
+}
+
+// 全練習室の 30 分枠ごとの状態を取得する
+func (c *Client) GetRoomSchedule(params *GetRoomScheduleParams) ([]RoomSchedule, error) {
+	return c.GetRoomScheduleContext(context.Background(), params)
+}
+
+func (c *Client) GetRoomScheduleContext(ctx context.Context, params *GetRoomScheduleParams) ([]RoomSchedule, error) {
+	now := c.now()
+
+	if !params.Campus.IsValid() {
+		return nil, ErrInvalidCampus
+	}
+	if !c.bookingPolicy.IsDateBookable(now, params.Date) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	var schedules []RoomSchedule
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		schedules, err = c.getRoomSchedule(ctx, params.Campus, params.Date, now)
+		return err
+	}))
+	return schedules, err
+}
+
+func (c *Client) getRoomSchedule(ctx context.Context, campus Campus, date Date, now time.Time) ([]RoomSchedule, error) {
+	pageURL, err := reserveURL(c.baseURL, campus, date)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return parseRoomSchedule(res.Body, c.index(), campus, date, now)
+}
+
+// 予約ページの URL
+func reserveURL(baseURL string, campus Campus, date Date) (string, error) {
+	u, err := url.Parse(baseURL + ENDPOINT_RESERVE)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("campus", string(campus))
+	q.Set("ymd", date.ToTime().Format("2006/01/02 15:04:05"))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// 予約ページとトップページの予約状況表のセルの class から枠の状態を求める
+//
+//	judgment1: 開館時間内（予約状況表で開館時間の範囲に付く。空いているかは分からない）
+//	judgment2: 他の利用者の予約
+//	judgment3: 自分の予約
+//	judgment4: チェックボックスか 〇 があれば空き、なければ時間外などで選択不可
+func slotStateFromClass(class string, selectable bool) (SlotState, bool) {
+	switch {
+	case strings.HasPrefix(class, "judgment1"):
+		return SlotStateOpen, true
+	case strings.HasPrefix(class, "judgment2"):
+		return SlotStateReservedByOthers, true
+	case strings.HasPrefix(class, "judgment3"):
+		return SlotStateReservedByMe, true
+	case strings.HasPrefix(class, "judgment4"):
+		if selectable {
+			return SlotStateFree, true
+		}
+		return SlotStateDisabled, true
+	default:
+		return "", false
+	}
+}
+
+// 予約ページの aspTable* の各行を部屋ごとの枠の状態に変換する
+// 練習室一覧にない部屋も、名前とチェックボックスから分かる ID だけを埋めて返す
+func parseRoomSchedule(r io.Reader, rooms *roomIndex, campus Campus, date Date, now time.Time) ([]RoomSchedule, error) {
+	z := html.NewTokenizer(r)
+
+	var schedules []RoomSchedule
+	var insideTable, insideRow bool
+	var colIndex int
+	var current *RoomSchedule
+
+	const baseHour = 7
+
+	isToday := date.Equals(FromTime(now))
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return schedules, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+
+			switch t.Data {
+			case "table":
+				for _, attr := range t.Attr {
+					if attr.Key == "id" && strings.HasPrefix(attr.Val, "aspTable") {
+						insideTable = true
+					}
+				}
+
+			case "tr":
+				if insideTable {
+					colIndex = 0
+					insideRow = true
+					current = nil
+				}
+
+			case "td":
+				if !insideRow {
+					break
+				}
+				colIndex++
+
+				// 部屋
+				if colIndex == 1 || current == nil {
+					continue
+				}
+
+				class := ""
+				for _, a := range t.Attr {
+					if a.Key == "class" {
+						class = a.Val
+					}
+				}
+
+				// 枠
+				selectable := false
+				depth := 1
+				for depth > 0 {
+					tt := z.Next()
+					switch tt {
+					case html.ErrorToken:
+						depth = 0
+					case html.StartTagToken, html.SelfClosingTagToken:
+						t := z.Token()
+						if t.Data == "input" {
+							disabled := false
+							for _, a := range t.Attr {
+								switch a.Key {
+								case "disabled":
+									disabled = true
+								case "name":
+									// チェックボックスの name は <部屋ID>,<HHMM>
+									if id, _, ok := strings.Cut(a.Val, ","); ok && current.Room.ID == "" {
+										current.Room.ID = id
+									}
+								}
+							}
+							if !disabled {
+								selectable = true
+							}
+						}
+					case html.TextToken:
+						if strings.Contains(z.Token().Data, "〇") {
+							selectable = true
+						}
+					case html.EndTagToken:
+						if z.Token().Data == "td" {
+							depth--
+						}
+					}
+				}
+
+				state, ok := slotStateFromClass(class, selectable)
+				if !ok {
+					continue
+				}
+
+				offset := colIndex - 2
+				hour := baseHour + (offset*30)/60
+				minute := (offset * 30) % 60
+
+				if isToday && state == SlotStateFree {
+					slotTime := time.Date(date.Year, date.Month, date.Day, hour, minute, 0, 0, jst)
+					if slotTime.Before(now) {
+						state = SlotStateDisabled
+					}
+				}
+
+				current.Slots = append(current.Slots, ScheduleSlot{
+					Hour:   hour,
+					Minute: minute,
+					State:  state,
+				})
+
+			case "span":
+				if insideRow && colIndex == 1 && current == nil {
+					if z.Next() == html.TextToken {
+						name := strings.TrimSpace(z.Token().Data)
+						if name == "" {
+							break
+						}
+						current = &RoomSchedule{Room: Room{
+							Name:      name,
+							PianoType: RoomPianoTypeUnknown,
+							Campus:    campus,
+						}}
+						if room, ok := rooms.findByName(campus, name); ok {
+							current.Room = room
+						}
+					}
+				}
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "tr":
+				if insideRow {
+					if current != nil {
+						schedules = append(schedules, *current)
+					}
+					insideRow = false
+				}
+			case "table":
+				insideTable = false
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package tcmrsv
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetRoomSchedule(t *testing.T) {
+	t.Run("SuccessfulScheduleRetrievalWithInputs", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		schedules, err := mockServer.Client.GetRoomSchedule(&GetRoomScheduleParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful schedule retrieval, got error: %v", err)
+		}
+
+		if len(schedules) == 0 {
+			t.Fatal("Expected room schedules to be returned, got empty slice")
+		}
+
+		// 7:00 から 23:00 まで 30 分ごとの枠がすべて含まれていることを確認
+		for _, schedule := range schedules {
+			if len(schedule.Slots) != 32 {
+				t.Errorf("Expected 32 slots for %s, got %d", schedule.Room.Name, len(schedule.Slots))
+			}
+		}
+
+		var p227 *RoomSchedule
+		for i := range schedules {
+			if schedules[i].Room.Name == "P 227（G）" {
+				p227 = &schedules[i]
+			}
+		}
+		if p227 == nil {
+			t.Fatal("Expected P 227（G） to be included")
+		}
+
+		expected := map[[2]int]SlotState{
+			{7, 0}:   SlotStateDisabled,
+			{10, 0}:  SlotStateFree,
+			{14, 30}: SlotStateReservedByOthers,
+			{16, 30}: SlotStateFree,
+			{22, 30}: SlotStateDisabled,
+		}
+		for _, slot := range p227.Slots {
+			if state, ok := expected[[2]int{slot.Hour, slot.Minute}]; ok && slot.State != state {
+				t.Errorf("Expected %02d:%02d to be %s, got %s", slot.Hour, slot.Minute, state, slot.State)
+			}
+		}
+	})
+
+	t.Run("SuccessfulScheduleRetrievalWithoutInputs", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_without_inputs.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		schedules, err := mockServer.Client.GetRoomSchedule(&GetRoomScheduleParams{
+			Campus: CampusIkebukuro,
+			Date:   Today().AddDays(1),
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful schedule retrieval, got error: %v", err)
+		}
+
+		counts := map[SlotState]int{}
+		for _, schedule := range schedules {
+			for _, slot := range schedule.Slots {
+				if !slot.State.IsValid() {
+					t.Errorf("Unexpected slot state: %q", slot.State)
+				}
+				counts[slot.State]++
+			}
+		}
+
+		if counts[SlotStateFree] == 0 || counts[SlotStateReservedByOthers] == 0 || counts[SlotStateReservedByMe] == 0 {
+			t.Errorf("Expected free, reserved and own slots, got %v", counts)
+		}
+
+		// 空きのない部屋も含まれるため、空き状況の部屋数以上になる
+		availabilities, err := mockServer.Client.GetRoomAvailability(&GetRoomAvailabilityParams{
+			Campus: CampusIkebukuro,
+			Date:   Today().AddDays(1),
+		})
+		if err != nil {
+			t.Fatalf("Expected successful availability retrieval, got error: %v", err)
+		}
+		if len(schedules) < len(availabilities) {
+			t.Errorf("Expected at least %d schedules, got %d", len(availabilities), len(schedules))
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Handler called despite validation errors")
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.GetRoomSchedule(&GetRoomScheduleParams{
+			Campus: Campus("invalid"),
+			Date:   Today().AddDays(1),
+		})
+		if err != ErrInvalidCampus {
+			t.Errorf("Expected invalid campus error, got: %v", err)
+		}
+
+		_, err = mockServer.Client.GetRoomSchedule(&GetRoomScheduleParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(4),
+		})
+		if err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error, got: %v", err)
+		}
+	})
+}
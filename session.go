@@ -0,0 +1,141 @@
+package tcmrsv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCookieJarUnavailable = errors.New("cookie jar unavailable error")
+	ErrSessionNotFound      = errors.New("session not found error")
+	ErrSessionHostMismatch  = errors.New("session host mismatch error")
+)
+
+// ログイン状態を保存・復元するためのスナップショット
+type Session struct {
+	BaseURL    string         `json:"base_url"`
+	Cookies    []*http.Cookie `json:"cookies"`
+	ASPConfig  ASPConfig      `json:"asp_config"`
+	LoggedInAt time.Time      `json:"logged_in_at"`
+}
+
+func (c *Client) ExportSession() (*Session, error) {
+	if c.httpClient.Jar == nil {
+		return nil, ErrCookieJarUnavailable
+	}
+
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &Session{
+		BaseURL:    c.baseURL,
+		Cookies:    c.httpClient.Jar.Cookies(u),
+		ASPConfig:  *c.aspConfig,
+		LoggedInAt: c.loggedInAt,
+	}, nil
+}
+
+// 別のサイト向けに保存されたセッションは取り込まない
+func (c *Client) ImportSession(session *Session) error {
+	if c.httpClient.Jar == nil {
+		return ErrCookieJarUnavailable
+	}
+	if strings.TrimSuffix(session.BaseURL, "/") != strings.TrimSuffix(c.baseURL, "/") {
+		return ErrSessionHostMismatch
+	}
+
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+
+	// cookiejar は Name と Value しか返さないため、サイト全体に送られるよう Path を補う
+	cookies := make([]*http.Cookie, 0, len(session.Cookies))
+	for _, cookie := range session.Cookies {
+		cc := *cookie
+		if cc.Path == "" {
+			cc.Path = "/"
+		}
+		cookies = append(cookies, &cc)
+	}
+	c.httpClient.Jar.SetCookies(u, cookies)
+
+	aspConfig := session.ASPConfig
+	c.mu.Lock()
+	c.aspConfig = &aspConfig
+	c.loggedInAt = session.LoggedInAt
+	c.mu.Unlock()
+
+	return nil
+}
+
+type SessionStore interface {
+	Load(ctx context.Context) (*Session, error)
+	Save(ctx context.Context, session *Session) error
+}
+
+// セッションを JSON ファイルとして保存する SessionStore
+type FileSessionStore struct {
+	Path string
+}
+
+func NewFileSessionStore(path string) *FileSessionStore {
+	return &FileSessionStore{Path: path}
+}
+
+func (s *FileSessionStore) Load(ctx context.Context) (*Session, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (s *FileSessionStore) Save(ctx context.Context, session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.Path, data)
+}
+
+// 書き込み途中のファイルを読まれないよう、一時ファイルに書いてからリネームする
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
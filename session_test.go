@@ -0,0 +1,133 @@
+package tcmrsv
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	routes := map[string]http.HandlerFunc{
+		"GET /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(LoadFixture("index.html")))
+		},
+		"POST /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "ASP.NET_SessionId", Value: "test_session", Path: "/"})
+			w.Write([]byte(LoadFixture("personal/facility/index.html")))
+		},
+		"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+			if cookie, err := r.Cookie("ASP.NET_SessionId"); err != nil || cookie.Value != "test_session" {
+				w.Write([]byte(LoadFixture("index.html")))
+				return
+			}
+			w.Write([]byte(LoadFixture("personal/facility/index.html")))
+		},
+	}
+
+	t.Run("ExportAndImport", func(t *testing.T) {
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		// Cookie を扱うため、cookiejar 付きのデフォルトの HTTP クライアントを使う
+		client := New(WithBaseURL(mockServer.Server.URL))
+
+		if err := client.Login(&LoginParams{UserID: "test_user", Password: "test_password"}); err != nil {
+			t.Fatalf("Expected successful login, got error: %v", err)
+		}
+
+		session, err := client.ExportSession()
+		if err != nil {
+			t.Fatalf("Expected successful export, got error: %v", err)
+		}
+
+		if session.LoggedInAt.IsZero() {
+			t.Error("Expected LoggedInAt to be set")
+		}
+
+		if session.ASPConfig.ViewState == "" {
+			t.Error("Expected ViewState to be exported")
+		}
+
+		// 新しいクライアントにセッションを引き継ぐ
+		restored := New(WithBaseURL(mockServer.Server.URL))
+		if err := restored.ImportSession(session); err != nil {
+			t.Fatalf("Expected successful import, got error: %v", err)
+		}
+
+		if _, err := restored.GetMyReservations(); err != nil {
+			t.Errorf("Expected imported session to be authenticated, got error: %v", err)
+		}
+	})
+
+	t.Run("HostMismatch", func(t *testing.T) {
+		client := New(WithBaseURL("https://example.com"))
+
+		err := client.ImportSession(&Session{
+			BaseURL: "https://www.tcmrsv.example",
+			Cookies: []*http.Cookie{{Name: "ASP.NET_SessionId", Value: "test_session"}},
+		})
+		if err != ErrSessionHostMismatch {
+			t.Errorf("Expected session host mismatch error, got: %v", err)
+		}
+	})
+
+	t.Run("WithoutCookieJar", func(t *testing.T) {
+		client := New(WithHTTPClient(&http.Client{}))
+
+		if _, err := client.ExportSession(); err != ErrCookieJarUnavailable {
+			t.Errorf("Expected cookie jar unavailable error, got: %v", err)
+		}
+
+		if err := client.ImportSession(&Session{}); err != ErrCookieJarUnavailable {
+			t.Errorf("Expected cookie jar unavailable error, got: %v", err)
+		}
+	})
+}
+
+func TestFileSessionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileSessionStore(filepath.Join(t.TempDir(), "session.json"))
+
+	if _, err := store.Load(ctx); err != ErrSessionNotFound {
+		t.Errorf("Expected session not found error, got: %v", err)
+	}
+
+	session := &Session{
+		BaseURL: "https://example.com",
+		Cookies: []*http.Cookie{{Name: "ASP.NET_SessionId", Value: "test_session"}},
+		ASPConfig: ASPConfig{
+			ViewState:          "view_state",
+			ViewStateGenerator: "generator",
+			EventValidation:    "event_validation",
+		},
+	}
+
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Expected successful save, got error: %v", err)
+	}
+
+	// キーは Session と同じ snake_case に揃える
+	data, err := os.ReadFile(store.Path)
+	if err != nil {
+		t.Fatalf("Expected saved file to be readable, got error: %v", err)
+	}
+	if !strings.Contains(string(data), `"view_state":"view_state"`) || strings.Contains(string(data), `"ViewState"`) {
+		t.Errorf("Expected snake_case keys for ASPConfig, got %s", data)
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Expected successful load, got error: %v", err)
+	}
+
+	if loaded.BaseURL != session.BaseURL || loaded.ASPConfig != session.ASPConfig {
+		t.Errorf("Expected loaded session to be %+v, got %+v", session, loaded)
+	}
+
+	if len(loaded.Cookies) != 1 || loaded.Cookies[0].Value != "test_session" {
+		t.Errorf("Expected cookie to be restored, got %+v", loaded.Cookies)
+	}
+}
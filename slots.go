@@ -0,0 +1,254 @@
+package tcmrsv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// 予約ページのチェックボックス 1 つ分（30 分枠）
+type SlotSelection struct {
+	RoomID string
+	Hour   int
+	Minute int
+}
+
+// チェックボックスの name 属性（<部屋ID>,<HHMM>）
+func (s SlotSelection) checkboxName() string {
+	return fmt.Sprintf("%s,%02d%02d", s.RoomID, s.Hour, s.Minute)
+}
+
+type ReserveSlotsParams struct {
+	Campus Campus
+	Date   Date
+	Slots  []SlotSelection
+}
+
+type ReserveSlotsResult struct {
+	// 予約できた枠
+	Booked []SlotSelection
+	// 予約できなかった枠
+	Failed []SlotSelection
+	// 練習室一覧にない部屋のため、完了ページから予約できたか判別できなかった枠
+	Unresolved []SlotSelection
+	// 完了ページに表示された予約内容
+	Reservations []ReservationPreview
+}
+
+// 予約ページで複数の枠をチェックして一度に予約する
+// 枠はすべて同じフロア（同じ予約ボタンの表）にある必要がある
+func (c *Client) ReserveSlots(params *ReserveSlotsParams) (*ReserveSlotsResult, error) {
+	return c.ReserveSlotsContext(context.Background(), params)
+}
+
+func (c *Client) ReserveSlotsContext(ctx context.Context, params *ReserveSlotsParams) (*ReserveSlotsResult, error) {
+	now := c.now()
+
+	if !params.Campus.IsValid() {
+		return nil, ErrInvalidCampus
+	}
+	if !c.bookingPolicy.IsDateBookable(now, params.Date) {
+		return nil, ErrDateOutOfRange
+	}
+	if len(params.Slots) == 0 {
+		return nil, ErrInvalidTimeRange
+	}
+	for _, slot := range params.Slots {
+		if !IsIDValid(slot.RoomID) {
+			return nil, ErrInvalidIDFormat
+		}
+		if !IsTimeRangeValid(slot.Hour, slot.Minute, slot.Hour+(slot.Minute+30)/60, (slot.Minute+30)%60) {
+			return nil, ErrInvalidTimeRange
+		}
+		if !IsTimeInFutureAt(now, slot.Hour, slot.Minute, params.Date) {
+			return nil, ErrTimeInPast
+		}
+	}
+
+	var entries []ReservationPreview
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		entries, err = c.reserveSlots(ctx, params)
+		return err
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReserveSlotsResult{Reservations: entries}
+	rooms := c.index()
+	for _, slot := range params.Slots {
+		room, ok := rooms.findByID(slot.RoomID)
+		switch {
+		case !ok:
+			result.Unresolved = append(result.Unresolved, slot)
+		case slotBooked(room, slot, entries):
+			result.Booked = append(result.Booked, slot)
+		default:
+			result.Failed = append(result.Failed, slot)
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) reserveSlots(ctx context.Context, params *ReserveSlotsParams) ([]ReservationPreview, error) {
+	pageURL, err := reserveURL(c.baseURL, params.Campus, params.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, aspConfig, err := c.doRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	buttons, err := parseReserveFormButtons(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 予約ボタンはフロアごとにあり、別のフロアの枠を同じボタンで送信できるかは分からないため、
+	// フロアをまたいだ枠は送信しない
+	form := url.Values{}
+	button := ""
+	for _, slot := range params.Slots {
+		name := slot.checkboxName()
+		b, ok := buttons[name]
+		if !ok {
+			return nil, fmt.Errorf("slot %s: %w", name, ErrSlotTaken)
+		}
+		if button == "" {
+			button = b
+		} else if b != button {
+			return nil, fmt.Errorf("slot %s: %w", name, ErrSlotsOnDifferentFloors)
+		}
+		form.Set(name, "on")
+	}
+
+	form.Set("__EVENTTARGET", "")
+	form.Set("__EVENTARGUMENT", "")
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
+	form.Set(button, "")
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, pageURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// 予約内容確認ページにリダイレクトされる
+	res, aspConfig, err = c.doRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if !strings.HasSuffix(res.Request.URL.Path, ENDPOINT_CONFIRMS) {
+		bodyBytes, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newServerError("reserve", ENDPOINT_RESERVE, bodyBytes, ErrCreateReservationFailed)
+	}
+
+	form = url.Values{}
+	form.Set("__VIEWSTATE", aspConfig.ViewState)
+	form.Set("__VIEWSTATEGENERATOR", aspConfig.ViewStateGenerator)
+	form.Set("__EVENTVALIDATION", aspConfig.EventValidation)
+	form.Set("KakuteiButton", "")
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, res.Request.URL.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err = c.DoRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(string(bodyBytes), "予約が完了しました") {
+		return nil, newServerError("reserve", ENDPOINT_CONFIRMS, bodyBytes, ErrCreateReservationFailed)
+	}
+
+	entries, err := parseReservationEntries(strings.NewReader(string(bodyBytes)))
+	return entries, err
+}
+
+// 予約ページの選択可能なチェックボックスと、それを送信するボタンの対応を返す
+func parseReserveFormButtons(r io.Reader) (map[string]string, error) {
+	z := html.NewTokenizer(r)
+
+	buttons := map[string]string{}
+	var pending []string
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return buttons, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if t.Data != "input" {
+				continue
+			}
+
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Key] = a.Val
+			}
+			if _, disabled := attrs["disabled"]; disabled {
+				continue
+			}
+
+			switch attrs["type"] {
+			case "checkbox":
+				pending = append(pending, attrs["name"])
+			case "submit":
+				// 各フロアの表の直後に、そのフロアの予約ボタンが置かれている
+				for _, name := range pending {
+					buttons[name] = attrs["name"]
+				}
+				pending = nil
+			}
+		}
+	}
+}
+
+// 完了ページの予約内容に room の slot が含まれているか（部屋名は正規化して比較する）
+func slotBooked(room Room, slot SlotSelection, entries []ReservationPreview) bool {
+	name := NormalizeRoomName(room.Name)
+	start := slot.Hour*60 + slot.Minute
+	for _, e := range entries {
+		if NormalizeRoomName(e.RoomName) != name {
+			continue
+		}
+		if e.FromHour*60+e.FromMinute <= start && start < e.ToHour*60+e.ToMinute {
+			return true
+		}
+	}
+	return false
+}
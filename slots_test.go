@@ -0,0 +1,246 @@
+package tcmrsv
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// 確認ページ・完了ページの予約内容を n 件に複製する
+func duplicateReservationList(page string, n int) string {
+	start := strings.Index(page, `<dl class="reservation-area">`)
+	end := start + strings.Index(page[start:], "</dl>") + len("</dl>")
+	return page[:start] + strings.Repeat(page[start:end], n) + page[end:]
+}
+
+func TestReserveSlots(t *testing.T) {
+	const roomID = "23f2e624-2f48-ec11-8c60-002248696fd6" // P 200（G）
+
+	newRoutes := func(t *testing.T) map[string]http.HandlerFunc {
+		return map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/reserve_with_inputs.html")))
+			},
+			"POST /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("Failed to parse form: %v", err)
+				}
+				for _, name := range []string{roomID + ",1200", roomID + ",1230"} {
+					if r.PostForm.Get(name) != "on" {
+						t.Errorf("Expected checkbox %s to be checked", name)
+					}
+				}
+				if _, ok := r.PostForm["Yoyaku1Button"]; !ok {
+					t.Errorf("Expected Yoyaku1Button to be submitted, got %v", r.PostForm)
+				}
+				http.Redirect(w, r, "/personal/facility/confirms.aspx", http.StatusFound)
+			},
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				// 完了ページには P 200（G）12:00-13:00 が表示される
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+		}
+	}
+
+	t.Run("SuccessfulReservation", func(t *testing.T) {
+		mockServer := NewMockServer(CreateHandler(newRoutes(t)))
+		defer mockServer.Close()
+
+		result, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots: []SlotSelection{
+				{RoomID: roomID, Hour: 12, Minute: 0},
+				{RoomID: roomID, Hour: 12, Minute: 30},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if len(result.Booked) != 2 || len(result.Failed) != 0 {
+			t.Errorf("Expected 2 booked slots, got booked=%v failed=%v", result.Booked, result.Failed)
+		}
+
+		if len(result.Reservations) != 1 || result.Reservations[0].RoomName != "P 200（G）" {
+			t.Errorf("Expected reservation for P 200（G）, got %+v", result.Reservations)
+		}
+	})
+
+	t.Run("PartiallyBooked", func(t *testing.T) {
+		routes := newRoutes(t)
+		routes["POST /personal/facility/reserve.aspx"] = func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/personal/facility/confirms.aspx", http.StatusFound)
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		result, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots: []SlotSelection{
+				{RoomID: roomID, Hour: 12, Minute: 30},
+				{RoomID: roomID, Hour: 13, Minute: 0},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if len(result.Booked) != 1 || result.Booked[0].Hour != 12 {
+			t.Errorf("Expected 12:30 to be booked, got %v", result.Booked)
+		}
+
+		if len(result.Failed) != 1 || result.Failed[0].Hour != 13 {
+			t.Errorf("Expected 13:00 to fail, got %v", result.Failed)
+		}
+	})
+
+	t.Run("MultipleEntries", func(t *testing.T) {
+		entries, err := parseReservationEntries(strings.NewReader(duplicateReservationList(LoadFixture("personal/facility/done.html"), 3)))
+		if err != nil {
+			t.Fatalf("Expected successful parse, got error: %v", err)
+		}
+
+		if len(entries) != 3 {
+			t.Errorf("Expected 3 entries, got %d", len(entries))
+		}
+	})
+
+	t.Run("UnavailableSlot", func(t *testing.T) {
+		mockServer := NewMockServer(CreateHandler(newRoutes(t)))
+		defer mockServer.Close()
+
+		// 7:00 の枠にはチェックボックスがない
+		_, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots:  []SlotSelection{{RoomID: roomID, Hour: 7, Minute: 0}},
+		})
+
+		if !errors.Is(err, ErrSlotTaken) {
+			t.Errorf("Expected slot taken error, got: %v", err)
+		}
+
+		if len(mockServer.Requests) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(mockServer.Requests))
+		}
+	})
+
+	t.Run("UnknownRoom", func(t *testing.T) {
+		// P 200（G）を含まない練習室一覧では、完了ページの予約内容と照合できない
+		catalog := &RoomCatalog{
+			Version: RoomCatalogVersion,
+			Rooms: []Room{
+				{ID: "5df2e624-2f48-ec11-8c60-002248696fd6", Name: "P 227（G）", Campus: CampusNakameguro},
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(newRoutes(t)), WithRoomCatalog(catalog))
+		defer mockServer.Close()
+
+		result, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots: []SlotSelection{
+				{RoomID: roomID, Hour: 12, Minute: 0},
+				{RoomID: roomID, Hour: 12, Minute: 30},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if len(result.Unresolved) != 2 || len(result.Booked) != 0 || len(result.Failed) != 0 {
+			t.Errorf("Expected 2 unresolved slots, got booked=%v failed=%v unresolved=%v", result.Booked, result.Failed, result.Unresolved)
+		}
+	})
+
+	t.Run("NormalizedRoomName", func(t *testing.T) {
+		// 練習室一覧と完了ページで全角・半角や空白が異なっていても照合できる
+		catalog := &RoomCatalog{
+			Version: RoomCatalogVersion,
+			Rooms: []Room{
+				{ID: roomID, Name: "P200(G)", Campus: CampusNakameguro},
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(newRoutes(t)), WithRoomCatalog(catalog))
+		defer mockServer.Close()
+
+		result, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots: []SlotSelection{
+				{RoomID: roomID, Hour: 12, Minute: 0},
+				{RoomID: roomID, Hour: 12, Minute: 30},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected successful reservation, got error: %v", err)
+		}
+
+		if len(result.Booked) != 2 || len(result.Failed) != 0 {
+			t.Errorf("Expected 2 booked slots, got booked=%v failed=%v", result.Booked, result.Failed)
+		}
+	})
+
+	t.Run("DifferentFloors", func(t *testing.T) {
+		mockServer := NewMockServer(CreateHandler(newRoutes(t)))
+		defer mockServer.Close()
+
+		// P 200（G）は Yoyaku1Button、C307 は Yoyaku3Button の表にある
+		_, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots: []SlotSelection{
+				{RoomID: roomID, Hour: 12, Minute: 0},
+				{RoomID: "1389ec71-7523-ed11-9db1-000d3a506b12", Hour: 14, Minute: 0},
+			},
+		})
+
+		if !errors.Is(err, ErrSlotsOnDifferentFloors) {
+			t.Errorf("Expected slots on different floors error, got: %v", err)
+		}
+
+		if len(mockServer.Requests) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(mockServer.Requests))
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// このハンドラーは入力検証エラーのため呼び出されるべきではない
+			t.Errorf("Handler called despite validation errors")
+		}))
+		defer mockServer.Close()
+
+		_, err := mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+		})
+
+		if err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error, got: %v", err)
+		}
+
+		_, err = mockServer.Client.ReserveSlots(&ReserveSlotsParams{
+			Campus: CampusNakameguro,
+			Date:   Today().AddDays(1),
+			Slots:  []SlotSelection{{RoomID: roomID, Hour: 12, Minute: 15}},
+		})
+
+		if err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error, got: %v", err)
+		}
+	})
+}
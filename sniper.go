@@ -0,0 +1,184 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type SniperOptions struct {
+	// 指定すると、解放時刻の LoginLead 前にこの認証情報でログインする
+	Login *LoginParams
+	// 解放時刻のどれだけ前にログインするか（PrefetchLead より短い場合は PrefetchLead）
+	LoginLead time.Duration
+	// 解放時刻のどれだけ前に予約内容確認ページを取得しておくか
+	PrefetchLead time.Duration
+	// 解放時刻以降に KakuteiButton を送信する最大回数
+	MaxAttempts int
+	// 送信に失敗してから次に送信するまでの間隔
+	RetryInterval time.Duration
+}
+
+func DefaultSniperOptions() SniperOptions {
+	return SniperOptions{
+		LoginLead:     time.Minute,
+		PrefetchLead:  5 * time.Second,
+		MaxAttempts:   10,
+		RetryInterval: 100 * time.Millisecond,
+	}
+}
+
+// 予約が解放される時刻ちょうどに予約を確定する
+type Sniper struct {
+	client    *Client
+	params    ReserveParams
+	options   SniperOptions
+	releaseAt time.Time
+}
+
+type SnipeResult struct {
+	// 予約を確定しようとした解放時刻
+	ReleaseAt time.Time
+	// 予約内容確認ページを取得した時刻
+	PrefetchedAt time.Time
+	// 最初に KakuteiButton を送信した時刻
+	FiredAt time.Time
+	// KakuteiButton を送信した回数
+	Attempts int
+	// 最後に送信した KakuteiButton の応答までにかかった時間
+	Latency time.Duration
+	// 解放時刻から予約が確定するまで（または最後の送信が失敗するまで）の時間
+	Elapsed time.Duration
+	// 作成された予約（予約を確定できなかった場合は nil）。
+	// 予約一覧から特定できなかった場合は Unresolved が true になる
+	Reservation *Reservation
+}
+
+// params の日付が次に解放される時刻に予約を確定する Sniper を作る
+// 既に予約できる日付の場合は、Run を呼んだ時点ですぐに予約を確定する
+func (c *Client) NewSniper(params *ReserveParams, options SniperOptions) (*Sniper, error) {
+	now := c.now()
+
+	releaseAt := now
+	if !c.bookingPolicy.IsDateBookable(now, params.Date) {
+		releaseAt = c.bookingPolicy.NextReleaseTime(now)
+	}
+
+	// 解放前の日付も、解放時刻の時点で予約できるかを検証する
+	if err := params.validate(releaseAt, c.bookingPolicy); err != nil {
+		return nil, err
+	}
+
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+
+	return &Sniper{
+		client:    c,
+		params:    *params,
+		options:   options,
+		releaseAt: releaseAt,
+	}, nil
+}
+
+func (s *Sniper) ReleaseAt() time.Time {
+	return s.releaseAt
+}
+
+// 解放時刻まで待って予約を確定する
+// 予約を確定できなかった場合も、それまでの経過を SnipeResult で返す
+func (s *Sniper) Run(ctx context.Context) (*SnipeResult, error) {
+	c := s.client
+	result := &SnipeResult{ReleaseAt: s.releaseAt}
+
+	// 長く待つ間にセッションが切れないよう、ログインは解放の直前まで遅らせる
+	if s.options.Login != nil {
+		if err := s.sleepUntil(ctx, s.releaseAt.Add(-max(s.options.LoginLead, s.options.PrefetchLead))); err != nil {
+			return result, err
+		}
+		if err := c.LoginContext(ctx, s.options.Login); err != nil {
+			return result, err
+		}
+	}
+
+	if err := s.sleepUntil(ctx, s.releaseAt.Add(-s.options.PrefetchLead)); err != nil {
+		return result, err
+	}
+
+	var (
+		confirmsURL string
+		aspConfig   *ASPConfig
+	)
+	err := c.withRetry(ctx, c.withRelogin(func(ctx context.Context) (err error) {
+		confirmsURL, aspConfig, err = c.prepareReservation(ctx, &s.params)
+		return err
+	}))
+	if err != nil {
+		return result, err
+	}
+	result.PrefetchedAt = c.now()
+
+	if err := s.sleepUntil(ctx, s.releaseAt); err != nil {
+		return result, err
+	}
+
+	// 解放時刻からの経過は単調時計で測る
+	fired := time.Now()
+	result.FiredAt = c.now()
+
+	for {
+		result.Attempts++
+
+		sent := time.Now()
+		next, err := c.confirmReservation(ctx, confirmsURL, aspConfig)
+		result.Latency = time.Since(sent)
+		result.Elapsed = time.Since(fired)
+
+		if err == nil {
+			break
+		}
+		if !isSnipeRetryable(err) || result.Attempts >= s.options.MaxAttempts {
+			return result, err
+		}
+
+		// 失敗したページのトークンで送り直す
+		if next != nil && next.ViewState != "" {
+			aspConfig = next
+		}
+
+		timer := time.NewTimer(s.options.RetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+	}
+
+	result.Reservation = c.resolveReservation(ctx, &s.params)
+	return result, nil
+}
+
+// 解放直後は時計のずれで予約できないことがあるため、予約の確定に失敗したら送り直す。
+// 画面のメッセージから失敗理由を判別できるとは限らないため、理由では区別しない
+func isSnipeRetryable(err error) bool {
+	return errors.Is(err, ErrCreateReservationFailed) || errors.Is(err, ErrInternalServer)
+}
+
+// クライアントの Clock で t になるまで待つ
+func (s *Sniper) sleepUntil(ctx context.Context, t time.Time) error {
+	d := t.Sub(s.client.now())
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,233 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSniper(t *testing.T) {
+	// 12:00 の解放直前
+	beforeRelease := time.Date(2025, time.May, 4, 11, 59, 59, 950_000_000, jst)
+	releaseDate := NewDate(2025, time.May, 6)
+
+	params := func(date Date) *ReserveParams {
+		return &ReserveParams{
+			Campus:     CampusIkebukuro,
+			RoomID:     "b9f2e624-2f48-ec11-8c60-002248696fd6", // A414（G）
+			Date:       date,
+			FromHour:   17,
+			FromMinute: 0,
+			ToHour:     22,
+			ToMinute:   30,
+		}
+	}
+
+	options := SniperOptions{
+		PrefetchLead:  5 * time.Second,
+		MaxAttempts:   3,
+		RetryInterval: time.Millisecond,
+	}
+
+	t.Run("SuccessfulSnipeAfterRetries", func(t *testing.T) {
+		var posts atomic.Int32
+
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				// 解放直後の 1 回目はまだ予約できない
+				if posts.Add(1) == 1 {
+					w.Write([]byte(LoadFixture("personal/facility/confirms_failure.html")))
+					return
+				}
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(reservationListFor(releaseDate)))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(NewFakeClock(beforeRelease)))
+		defer mockServer.Close()
+
+		// 解放前の日付でも準備できる
+		if _, err := mockServer.Client.Reserve(params(releaseDate)); err == nil {
+			t.Fatal("Expected the date to be unavailable before release")
+		}
+
+		sniper, err := mockServer.Client.NewSniper(params(releaseDate), options)
+		if err != nil {
+			t.Fatalf("Expected sniper to be created, got error: %v", err)
+		}
+
+		expectedRelease := time.Date(2025, time.May, 4, 12, 0, 0, 0, jst)
+		if !sniper.ReleaseAt().Equal(expectedRelease) {
+			t.Errorf("Expected release at %v, got %v", expectedRelease, sniper.ReleaseAt())
+		}
+
+		result, err := sniper.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Expected successful snipe, got error: %v", err)
+		}
+
+		if result.Attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", result.Attempts)
+		}
+		if result.Reservation == nil || result.Reservation.ID != "fa791156-cc27-f011-8c4e-000d3ace9c3e" {
+			t.Errorf("Unexpected reservation: %+v", result.Reservation)
+		}
+		if result.Latency <= 0 || result.Elapsed < result.Latency {
+			t.Errorf("Unexpected latency %v and elapsed %v", result.Latency, result.Elapsed)
+		}
+
+		// 予約内容確認ページは解放前に 1 回だけ取得する
+		gets := 0
+		for _, req := range mockServer.Requests {
+			if req.Method == http.MethodGet && req.URL.Path == "/personal/facility/confirms.aspx" {
+				gets++
+			}
+		}
+		if gets != 1 {
+			t.Errorf("Expected confirms page to be fetched once, got %d", gets)
+		}
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms_failure.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(NewFakeClock(beforeRelease)))
+		defer mockServer.Close()
+
+		sniper, err := mockServer.Client.NewSniper(params(releaseDate), options)
+		if err != nil {
+			t.Fatalf("Expected sniper to be created, got error: %v", err)
+		}
+
+		result, err := sniper.Run(context.Background())
+		if !errors.Is(err, ErrCreateReservationFailed) {
+			t.Errorf("Expected create reservation failure, got: %v", err)
+		}
+		if result.Attempts != options.MaxAttempts {
+			t.Errorf("Expected %d attempts, got %d", options.MaxAttempts, result.Attempts)
+		}
+		if result.Reservation != nil {
+			t.Errorf("Expected no reservation, got %+v", result.Reservation)
+		}
+	})
+
+	t.Run("DateNotReleasedNext", func(t *testing.T) {
+		mockServer := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Handler called despite validation errors")
+		}), WithClock(NewFakeClock(beforeRelease)))
+		defer mockServer.Close()
+
+		// 次の解放でも予約できない日付は拒否する
+		if _, err := mockServer.Client.NewSniper(params(releaseDate.AddDays(1)), options); err == nil {
+			t.Error("Expected validation error for a date beyond the next release")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+		}
+
+		// 準備の前に待つ必要がある時刻
+		clock := NewFakeClock(time.Date(2025, time.May, 4, 11, 0, 0, 0, jst))
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(clock))
+		defer mockServer.Close()
+
+		sniper, err := mockServer.Client.NewSniper(params(releaseDate), options)
+		if err != nil {
+			t.Fatalf("Expected sniper to be created, got error: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := sniper.Run(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Expected deadline exceeded, got: %v", err)
+		}
+		if len(mockServer.Requests) != 0 {
+			t.Errorf("Expected no requests, got %d", len(mockServer.Requests))
+		}
+	})
+
+	t.Run("LogsInShortlyBeforeRelease", func(t *testing.T) {
+		routes := map[string]http.HandlerFunc{
+			"GET /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("index.html")))
+			},
+			"POST /index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/index.html")))
+			},
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+		}
+
+		clock := NewFakeClock(time.Date(2025, time.May, 4, 11, 0, 0, 0, jst))
+		mockServer := NewMockServer(CreateHandler(routes), WithClock(clock))
+		defer mockServer.Close()
+
+		options := options
+		options.Login = &LoginParams{UserID: "test_user", Password: "test_password"}
+		options.LoginLead = time.Minute
+
+		sniper, err := mockServer.Client.NewSniper(params(releaseDate), options)
+		if err != nil {
+			t.Fatalf("Expected sniper to be created, got error: %v", err)
+		}
+
+		// 1 時間前にはまだログインしない
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := sniper.Run(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Expected deadline exceeded, got: %v", err)
+		}
+		if len(mockServer.Requests) != 0 {
+			t.Errorf("Expected no requests before the login time, got %d", len(mockServer.Requests))
+		}
+
+		// LoginLead の範囲に入ればログインしてから予約内容確認ページを取得する
+		clock.Set(time.Date(2025, time.May, 4, 11, 59, 0, 0, jst))
+		sniper, err = mockServer.Client.NewSniper(params(releaseDate), options)
+		if err != nil {
+			t.Fatalf("Expected sniper to be created, got error: %v", err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		if _, err := sniper.Run(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Expected deadline exceeded while waiting for the prefetch, got: %v", err)
+		}
+		logins := 0
+		for _, req := range mockServer.Requests {
+			if req.URL.Path == "/personal/facility/confirms.aspx" {
+				t.Error("Expected confirms page not to be fetched before the prefetch time")
+			}
+			if req.Method == http.MethodPost && req.URL.Path == "/index.aspx" {
+				logins++
+			}
+		}
+		if logins != 1 {
+			t.Errorf("Expected 1 login, got %d", logins)
+		}
+	})
+}
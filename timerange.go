@@ -0,0 +1,232 @@
+package tcmrsv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTimeFormat = errors.New("invalid time format, expected HH:MM on the half hour")
+)
+
+// 1 日のうちの時刻（30 分単位）
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+func NewTimeOfDay(hour, minute int) (TimeOfDay, error) {
+	t := TimeOfDay{Hour: hour, Minute: minute}
+	if !t.IsValid() {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+	return t, nil
+}
+
+// "17:30" のような文字列を読み取る
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	hour, minute, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || len(minute) != 2 || len(hour) == 0 || len(hour) > 2 {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+	return NewTimeOfDay(h, m)
+}
+
+func timeOfDayFromMinutes(m int) TimeOfDay {
+	return TimeOfDay{Hour: m / 60, Minute: m % 60}
+}
+
+// 0:00 から 24:00 までの 30 分単位の時刻か
+func (t TimeOfDay) IsValid() bool {
+	if t.Minute != 0 && t.Minute != 30 {
+		return false
+	}
+	return t.Hour >= 0 && (t.Hour < 24 || t.Hour == 24 && t.Minute == 0)
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// 0:00 からの経過分数
+func (t TimeOfDay) Minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// d だけ後（負なら前）の時刻を返す。
+// d が 30 分単位でない場合や、結果が 0:00 から 24:00 の範囲を外れる場合は ErrInvalidTimeFormat を返す
+func (t TimeOfDay) Add(d time.Duration) (TimeOfDay, error) {
+	if d%(30*time.Minute) != 0 {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+
+	result := timeOfDayFromMinutes(t.Minutes() + int(d/time.Minute))
+	if !result.IsValid() {
+		return TimeOfDay{}, ErrInvalidTimeFormat
+	}
+	return result, nil
+}
+
+func (t TimeOfDay) IsBefore(other TimeOfDay) bool {
+	return t.Minutes() < other.Minutes()
+}
+
+func (t TimeOfDay) IsAfter(other TimeOfDay) bool {
+	return t.Minutes() > other.Minutes()
+}
+
+func (t TimeOfDay) AvailableTime() AvailableTime {
+	return AvailableTime{Hour: t.Hour, Minute: t.Minute}
+}
+
+func (t AvailableTime) TimeOfDay() TimeOfDay {
+	return TimeOfDay{Hour: t.Hour, Minute: t.Minute}
+}
+
+func (s ScheduleSlot) TimeOfDay() TimeOfDay {
+	return TimeOfDay{Hour: s.Hour, Minute: s.Minute}
+}
+
+// From から To まで（To は含まない）の時間帯
+type TimeRange struct {
+	From TimeOfDay
+	To   TimeOfDay
+}
+
+// 開館時間
+var OpeningHours = TimeRange{
+	From: TimeOfDay{Hour: 7, Minute: 0},
+	To:   TimeOfDay{Hour: 23, Minute: 0},
+}
+
+// 予約できる時間帯を作る
+func NewTimeRange(from, to TimeOfDay) (TimeRange, error) {
+	r := TimeRange{From: from, To: to}
+	if err := r.Validate(); err != nil {
+		return TimeRange{}, err
+	}
+	return r, nil
+}
+
+// "17:00-22:30" のような文字列を読み取る
+func ParseTimeRange(s string) (TimeRange, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return TimeRange{}, ErrInvalidTimeFormat
+	}
+
+	f, err := ParseTimeOfDay(from)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	t, err := ParseTimeOfDay(to)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	return NewTimeRange(f, t)
+}
+
+// 開館時間内の 30 分単位で、終了が開始より後の時間帯か
+func (r TimeRange) Validate() error {
+	if !IsTimeRangeValid(r.From.Hour, r.From.Minute, r.To.Hour, r.To.Minute) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+func (r TimeRange) String() string {
+	return r.From.String() + "-" + r.To.String()
+}
+
+func (r TimeRange) Duration() time.Duration {
+	return time.Duration(r.To.Minutes()-r.From.Minutes()) * time.Minute
+}
+
+// 2 つの時間帯が重なっているか（終了と開始が同じ時刻なら重ならない）
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.From.IsBefore(other.To) && other.From.IsBefore(r.To)
+}
+
+// t が時間帯に含まれるか（終了時刻は含まない）
+func (r TimeRange) Contains(t TimeOfDay) bool {
+	return !t.IsBefore(r.From) && t.IsBefore(r.To)
+}
+
+// 時間帯を構成する 30 分枠の開始時刻
+func (r TimeRange) Slots() []TimeOfDay {
+	var slots []TimeOfDay
+	for m := r.From.Minutes(); m < r.To.Minutes(); m += 30 {
+		slots = append(slots, timeOfDayFromMinutes(m))
+	}
+	return slots
+}
+
+func (p *ReserveParams) TimeRange() TimeRange {
+	return TimeRange{
+		From: TimeOfDay{Hour: p.FromHour, Minute: p.FromMinute},
+		To:   TimeOfDay{Hour: p.ToHour, Minute: p.ToMinute},
+	}
+}
+
+func (p *ReserveParams) SetTimeRange(r TimeRange) {
+	p.FromHour, p.FromMinute = r.From.Hour, r.From.Minute
+	p.ToHour, p.ToMinute = r.To.Hour, r.To.Minute
+}
+
+func (p *changeReservationParams) TimeRange() TimeRange {
+	return TimeRange{
+		From: TimeOfDay{Hour: p.FromHour, Minute: p.FromMinute},
+		To:   TimeOfDay{Hour: p.ToHour, Minute: p.ToMinute},
+	}
+}
+
+func (p *changeReservationParams) SetTimeRange(r TimeRange) {
+	p.FromHour, p.FromMinute = r.From.Hour, r.From.Minute
+	p.ToHour, p.ToMinute = r.To.Hour, r.To.Minute
+}
+
+func (r *Reservation) TimeRange() TimeRange {
+	return TimeRange{
+		From: TimeOfDay{Hour: r.FromHour, Minute: r.FromMinute},
+		To:   TimeOfDay{Hour: r.ToHour, Minute: r.ToMinute},
+	}
+}
+
+func (p *ReservationPreview) TimeRange() TimeRange {
+	return TimeRange{
+		From: TimeOfDay{Hour: p.FromHour, Minute: p.FromMinute},
+		To:   TimeOfDay{Hour: p.ToHour, Minute: p.ToMinute},
+	}
+}
+
+// 監視する時間帯。指定していなければ開館時間
+func (t *WatchTarget) TimeRange() TimeRange {
+	if !t.hasTimeRange() {
+		return OpeningHours
+	}
+	return TimeRange{
+		From: TimeOfDay{Hour: t.FromHour, Minute: t.FromMinute},
+		To:   TimeOfDay{Hour: t.ToHour, Minute: t.ToMinute},
+	}
+}
+
+func (t *WatchTarget) SetTimeRange(r TimeRange) {
+	t.FromHour, t.FromMinute = r.From.Hour, r.From.Minute
+	t.ToHour, t.ToMinute = r.To.Hour, r.To.Minute
+}
+
+func (b FreeBlock) TimeRange() TimeRange {
+	return TimeRange{From: b.From.TimeOfDay(), To: b.To.TimeOfDay()}
+}
@@ -0,0 +1,152 @@
+package tcmrsv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TimeOfDay
+		wantErr  bool
+	}{
+		{"17:30", TimeOfDay{17, 30}, false},
+		{"7:00", TimeOfDay{7, 0}, false},
+		{" 09:00 ", TimeOfDay{9, 0}, false},
+		{"24:00", TimeOfDay{24, 0}, false},
+		{"17:15", TimeOfDay{}, true},
+		{"24:30", TimeOfDay{}, true},
+		{"1730", TimeOfDay{}, true},
+		{"17:3", TimeOfDay{}, true},
+		{"ab:00", TimeOfDay{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeOfDay(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeOfDay(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseTimeOfDay(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+		if !tt.wantErr && got.String() != tt.expected.String() {
+			t.Errorf("Expected %v to round-trip, got %s", tt.expected, got.String())
+		}
+	}
+
+	if s := (TimeOfDay{7, 0}).String(); s != "07:00" {
+		t.Errorf("Expected 07:00, got %s", s)
+	}
+}
+
+func TestTimeOfDayAdd(t *testing.T) {
+	tests := []struct {
+		from     TimeOfDay
+		d        time.Duration
+		expected TimeOfDay
+		wantErr  bool
+	}{
+		{TimeOfDay{17, 30}, 90 * time.Minute, TimeOfDay{19, 0}, false},
+		{TimeOfDay{7, 0}, -30 * time.Minute, TimeOfDay{6, 30}, false},
+		{TimeOfDay{23, 30}, 30 * time.Minute, TimeOfDay{24, 0}, false},
+		{TimeOfDay{7, 0}, 17 * time.Minute, TimeOfDay{}, true},
+		{TimeOfDay{0, 0}, -30 * time.Minute, TimeOfDay{}, true},
+		{TimeOfDay{24, 0}, 30 * time.Minute, TimeOfDay{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.from.Add(tt.d)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v.Add(%v) error = %v, wantErr %v", tt.from, tt.d, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%v.Add(%v) = %v, expected %v", tt.from, tt.d, got, tt.expected)
+		}
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	r, err := ParseTimeRange("17:00-22:30")
+	if err != nil {
+		t.Fatalf("Expected successful parse, got error: %v", err)
+	}
+
+	if r.Duration() != 5*time.Hour+30*time.Minute {
+		t.Errorf("Expected 5h30m, got %v", r.Duration())
+	}
+	if r.String() != "17:00-22:30" {
+		t.Errorf("Expected 17:00-22:30, got %s", r.String())
+	}
+
+	slots := r.Slots()
+	if len(slots) != 11 || slots[0] != (TimeOfDay{17, 0}) || slots[10] != (TimeOfDay{22, 0}) {
+		t.Errorf("Unexpected slots: %v", slots)
+	}
+
+	if !r.Contains(TimeOfDay{17, 0}) || !r.Contains(TimeOfDay{22, 0}) || r.Contains(TimeOfDay{22, 30}) || r.Contains(TimeOfDay{16, 30}) {
+		t.Error("Expected range to contain its start and exclude its end")
+	}
+
+	overlaps := []struct {
+		other    TimeRange
+		expected bool
+	}{
+		{TimeRange{TimeOfDay{16, 0}, TimeOfDay{17, 30}}, true},
+		{TimeRange{TimeOfDay{18, 0}, TimeOfDay{19, 0}}, true},
+		{TimeRange{TimeOfDay{22, 0}, TimeOfDay{23, 0}}, true},
+		// 接しているだけなら重ならない
+		{TimeRange{TimeOfDay{16, 0}, TimeOfDay{17, 0}}, false},
+		{TimeRange{TimeOfDay{22, 30}, TimeOfDay{23, 0}}, false},
+	}
+	for _, tt := range overlaps {
+		if got := r.Overlaps(tt.other); got != tt.expected {
+			t.Errorf("Overlaps(%v) = %v, expected %v", tt.other, got, tt.expected)
+		}
+	}
+
+	// 開館時間外や逆転した時間帯は作れない
+	for _, s := range []string{"6:30-8:00", "22:00-23:30", "18:00-17:00", "18:00-18:00", "18:00"} {
+		if _, err := ParseTimeRange(s); err == nil {
+			t.Errorf("Expected %q to be rejected", s)
+		}
+	}
+	if _, err := NewTimeRange(TimeOfDay{22, 0}, TimeOfDay{24, 0}); err != ErrInvalidTimeRange {
+		t.Errorf("Expected invalid time range error, got: %v", err)
+	}
+}
+
+func TestTimeRangeConversions(t *testing.T) {
+	r := TimeRange{From: TimeOfDay{18, 30}, To: TimeOfDay{20, 0}}
+
+	params := &ReserveParams{}
+	params.SetTimeRange(r)
+	if params.FromHour != 18 || params.FromMinute != 30 || params.ToHour != 20 || params.ToMinute != 0 {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+	if params.TimeRange() != r {
+		t.Errorf("Expected %v, got %v", r, params.TimeRange())
+	}
+
+	reservation := &Reservation{FromHour: 18, FromMinute: 30, ToHour: 20, ToMinute: 0}
+	if reservation.TimeRange() != r {
+		t.Errorf("Expected %v, got %v", r, reservation.TimeRange())
+	}
+
+	at := AvailableTime{Hour: 18, Minute: 30}
+	if at.TimeOfDay() != r.From || r.From.AvailableTime() != at {
+		t.Errorf("Expected %v to convert to and from %v", at, r.From)
+	}
+
+	// 時間帯を指定していない監視対象は開館時間全体
+	target := &WatchTarget{}
+	if target.TimeRange() != OpeningHours {
+		t.Errorf("Expected opening hours, got %v", target.TimeRange())
+	}
+	target.SetTimeRange(r)
+	if target.TimeRange() != r {
+		t.Errorf("Expected %v, got %v", r, target.TimeRange())
+	}
+}
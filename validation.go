@@ -12,19 +12,7 @@ func IsIDValid(ID string) bool {
 }
 
 func IsDateWithin2Days(now time.Time, date Date) bool {
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, jst)
-
-	// 3日後の予約が解放されるのは、午後12時から
-	isMorning := now.Hour() < 12
-
-	var endDate time.Time
-	if isMorning {
-		endDate = today.AddDate(0, 0, 1)
-	} else {
-		endDate = today.AddDate(0, 0, 2)
-	}
-
-	return !date.IsBefore(FromTime(today)) && !date.IsAfter(FromTime(endDate))
+	return DefaultBookingPolicy().IsDateBookable(now, date)
 }
 
 func IsTimeRangeValid(fromHour, fromMinute, toHour, toMinute int) bool {
@@ -55,7 +43,12 @@ func IsTimeRangeValid(fromHour, fromMinute, toHour, toMinute int) bool {
 }
 
 func IsTimeInFuture(fromHour, fromMinute int, date Date) bool {
-	now := time.Now().In(jst)
+	return IsTimeInFutureAt(time.Now(), fromHour, fromMinute, date)
+}
+
+// now の時点で date の fromHour:fromMinute が過ぎていないか
+func IsTimeInFutureAt(now time.Time, fromHour, fromMinute int, date Date) bool {
+	now = now.In(jst)
 	if date.Equals(FromTime(now)) {
 		currentTotal := now.Hour()*60 + now.Minute()
 		fromTotal := fromHour*60 + fromMinute
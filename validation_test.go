@@ -110,6 +110,29 @@ func TestIsTimeInFuture(t *testing.T) {
 	}
 }
 
+func TestIsTimeInFutureAt(t *testing.T) {
+	now := time.Date(2025, time.May, 4, 12, 15, 0, 0, jst)
+	today := NewDate(2025, time.May, 4)
+
+	tests := []struct {
+		fromH, fromM int
+		date         Date
+		want         bool
+	}{
+		{12, 30, today, true},
+		{12, 0, today, false},
+		// 翌日の枠は時刻に関係なく未来
+		{7, 0, today.AddDays(1), true},
+		{22, 0, today.AddDays(-1), true},
+	}
+
+	for _, tt := range tests {
+		if got := IsTimeInFutureAt(now, tt.fromH, tt.fromM, tt.date); got != tt.want {
+			t.Errorf("IsTimeInFutureAt(%v %02d:%02d) = %v; want %v", tt.date, tt.fromH, tt.fromM, got, tt.want)
+		}
+	}
+}
+
 func TestIsCommentValid(t *testing.T) {
 	tests := []struct {
 		comment string
@@ -0,0 +1,390 @@
+package tcmrsv
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// 空き状況を取得する間隔の下限。サーバーに負荷をかけないよう、これより短い間隔は切り上げる
+const minWatchInterval = 10 * time.Second
+
+// 監視する空き状況
+type WatchTarget struct {
+	Campus Campus
+	Date   Date
+	// 監視する練習室の条件（nil ならキャンパスのすべての練習室）
+	Rooms *GetRoomsFilteredParams
+	// 監視する時間帯（すべて 0 なら終日）
+	FromHour   int
+	FromMinute int
+	ToHour     int
+	ToMinute   int
+	// 時間帯全体が空いた練習室を予約する（時間帯の指定が必要）
+	AutoReserve bool
+}
+
+func (t *WatchTarget) hasTimeRange() bool {
+	return t.FromHour != 0 || t.FromMinute != 0 || t.ToHour != 0 || t.ToMinute != 0
+}
+
+func (t *WatchTarget) containsTime(at AvailableTime) bool {
+	return t.TimeRange().Contains(at.TimeOfDay())
+}
+
+func (t *WatchTarget) validate() error {
+	if !t.Campus.IsValid() {
+		return ErrInvalidCampus
+	}
+	if err := t.TimeRange().Validate(); err != nil {
+		return err
+	}
+	if t.AutoReserve && !t.hasTimeRange() {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+type WatcherOptions struct {
+	Targets []WatchTarget
+	// 空き状況を取得する間隔（minWatchInterval より短い場合は切り上げる）
+	Interval time.Duration
+	// 間隔をランダムに延長する割合（0〜1）
+	Jitter float64
+	// ErrInternalServer が続いたときに間隔を延ばす方針（MaxAttempts と OperationTimeout は使わない）
+	Backoff RetryPolicy
+}
+
+func DefaultWatcherOptions() WatcherOptions {
+	return WatcherOptions{
+		Interval: 30 * time.Second,
+		Jitter:   0.2,
+		Backoff: RetryPolicy{
+			InitialBackoff: time.Minute,
+			MaxBackoff:     10 * time.Minute,
+			Multiplier:     2,
+			Jitter:         0.5,
+		},
+	}
+}
+
+type WatchEventType string
+
+const (
+	// 監視している時間帯の枠が新たに空いた
+	WatchEventSlotFreed WatchEventType = "slot_freed"
+	// 空いた枠を予約した
+	WatchEventReserved WatchEventType = "reserved"
+	// 空いた枠を予約できなかった
+	WatchEventReserveFailed WatchEventType = "reserve_failed"
+	// 空き状況を取得できなかった
+	WatchEventError WatchEventType = "error"
+)
+
+func (t WatchEventType) IsValid() bool {
+	switch t {
+	case WatchEventSlotFreed, WatchEventReserved, WatchEventReserveFailed, WatchEventError:
+		return true
+	}
+	return false
+}
+
+type WatchEvent struct {
+	Type   WatchEventType
+	Target WatchTarget
+	// 空いた練習室（WatchEventSlotFreed、WatchEventReserved）
+	Room Room
+	// 新たに空いた枠（WatchEventSlotFreed）
+	Times []AvailableTime
+	// 作成された予約（WatchEventReserved）
+	Reservation *Reservation
+	// 失敗の理由（WatchEventReserveFailed、WatchEventError）
+	Err error
+	At  time.Time
+}
+
+// 空き状況を定期的に取得し、キャンセルなどで空いた枠を通知する
+type Watcher struct {
+	client  *Client
+	options WatcherOptions
+	events  chan WatchEvent
+
+	// 取得する間隔の下限（テストでは短くする）
+	minInterval time.Duration
+
+	// 対象ごとの前回の空き枠（練習室 ID → 枠）。最初の取得までは nil
+	previous []map[string]map[AvailableTime]bool
+	// 予約が完了した、または日付が過ぎた対象
+	done []bool
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+func (c *Client) NewWatcher(options WatcherOptions) (*Watcher, error) {
+	for i := range options.Targets {
+		if err := options.Targets[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Watcher{
+		client:      c,
+		options:     options,
+		events:      make(chan WatchEvent, 16),
+		minInterval: minWatchInterval,
+		previous:    make([]map[string]map[AvailableTime]bool, len(options.Targets)),
+		done:        make([]bool, len(options.Targets)),
+		stopped:     make(chan struct{}),
+	}, nil
+}
+
+// 通知を受け取るチャネル。Run が終了すると閉じられる。
+// 受け取らなくても監視は続くが、溜まった通知は古いものから捨てられる
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// ctx が終了するか Stop が呼ばれるまで、またはすべての対象の監視が終わるまで監視する
+// 既に実行中の場合は ErrWatcherRunning、終了した後は ErrWatcherFinished を返す
+func (w *Watcher) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.mu.Unlock()
+		cancel()
+
+		// 通知のチャネルは閉じているため、同じ Watcher では再開できない
+		select {
+		case <-w.stopped:
+			return ErrWatcherFinished
+		default:
+			return ErrWatcherRunning
+		}
+	}
+	w.cancel = cancel
+	w.mu.Unlock()
+
+	defer close(w.stopped)
+	defer close(w.events)
+	defer cancel()
+
+	failures := 0
+	for {
+		err := w.poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if w.finished() {
+			return nil
+		}
+
+		wait := w.interval()
+		if errors.Is(err, ErrInternalServer) {
+			failures++
+			wait = max(wait, w.options.Backoff.backoff(failures))
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// 実行中の取得や予約が終わるのを待って監視を止める
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-w.stopped
+}
+
+func (w *Watcher) interval() time.Duration {
+	d := float64(max(w.options.Interval, w.minInterval))
+	if w.options.Jitter > 0 {
+		d += d * min(w.options.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+func (w *Watcher) finished() bool {
+	for _, done := range w.done {
+		if !done {
+			return false
+		}
+	}
+	return true
+}
+
+// すべての対象の空き状況を 1 回ずつ取得する。ErrInternalServer があればそれを返す
+func (w *Watcher) poll(ctx context.Context) error {
+	c := w.client
+
+	var internalErr error
+	for i := range w.options.Targets {
+		if w.done[i] {
+			continue
+		}
+		target := &w.options.Targets[i]
+
+		now := c.now()
+		if target.Date.IsBefore(FromTime(now)) {
+			w.done[i] = true
+			continue
+		}
+		// まだ解放されていない日付は解放されるまで待つ
+		if !c.bookingPolicy.IsDateBookable(now, target.Date) {
+			continue
+		}
+
+		availabilities, err := c.GetRoomAvailabilityContext(ctx, &GetRoomAvailabilityParams{
+			Campus: target.Campus,
+			Date:   target.Date,
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if errors.Is(err, ErrInternalServer) {
+				internalErr = err
+			}
+			w.emit(WatchEvent{Type: WatchEventError, Target: *target, Err: err, At: c.now()})
+			continue
+		}
+
+		w.check(ctx, i, w.filter(target, availabilities))
+	}
+	return internalErr
+}
+
+// 対象の練習室と時間帯の空き枠だけを残す
+func (w *Watcher) filter(target *WatchTarget, availabilities []RoomAvailability) []RoomAvailability {
+	var rooms map[string]bool
+	if target.Rooms != nil {
+		rooms = map[string]bool{}
+		for _, room := range w.client.GetRoomsFiltered(*target.Rooms) {
+			rooms[room.ID] = true
+		}
+	}
+
+	var result []RoomAvailability
+	for _, a := range availabilities {
+		if rooms != nil && !rooms[a.Room.ID] {
+			continue
+		}
+
+		filtered := RoomAvailability{Room: a.Room}
+		for _, t := range a.AvailableTimes {
+			if target.containsTime(t) {
+				filtered.AvailableTimes = append(filtered.AvailableTimes, t)
+			}
+		}
+		if len(filtered.AvailableTimes) > 0 {
+			result = append(result, filtered)
+		}
+	}
+	return result
+}
+
+// 前回から新たに空いた枠を通知し、必要なら予約する
+func (w *Watcher) check(ctx context.Context, i int, availabilities []RoomAvailability) {
+	c := w.client
+	target := &w.options.Targets[i]
+	previous := w.previous[i]
+
+	current := make(map[string]map[AvailableTime]bool, len(availabilities))
+	for _, a := range availabilities {
+		times := make(map[AvailableTime]bool, len(a.AvailableTimes))
+		for _, t := range a.AvailableTimes {
+			times[t] = true
+		}
+		current[a.Room.ID] = times
+
+		// 最初の取得は基準にするだけで通知しない
+		if previous == nil {
+			continue
+		}
+
+		var freed []AvailableTime
+		for _, t := range a.AvailableTimes {
+			if !previous[a.Room.ID][t] {
+				freed = append(freed, t)
+			}
+		}
+		if len(freed) > 0 {
+			w.emit(WatchEvent{Type: WatchEventSlotFreed, Target: *target, Room: a.Room, Times: freed, At: c.now()})
+		}
+	}
+	w.previous[i] = current
+
+	if !target.AutoReserve {
+		return
+	}
+
+	var candidates []ReserveParams
+	for _, a := range availabilities {
+		candidates = append(candidates, ReserveParams{
+			Campus:     target.Campus,
+			RoomID:     a.Room.ID,
+			Date:       target.Date,
+			FromHour:   target.FromHour,
+			FromMinute: target.FromMinute,
+			ToHour:     target.ToHour,
+			ToMinute:   target.ToMinute,
+		})
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	// 取得したばかりの空き状況を使い、予約ページを取得し直さない
+	result, err := c.reserveFirstAvailable(ctx, candidates, func(Campus, Date) ([]RoomAvailability, error) {
+		return availabilities, nil
+	})
+	if err == nil {
+		w.done[i] = true
+		w.emit(WatchEvent{
+			Type:        WatchEventReserved,
+			Target:      *target,
+			Room:        availabilities[result.Index].Room,
+			Reservation: result.Reservation,
+			At:          c.now(),
+		})
+		return
+	}
+	// 時間帯全体が空いている練習室がないのは失敗ではない
+	if err != ErrNoAvailableCandidate {
+		w.emit(WatchEvent{Type: WatchEventReserveFailed, Target: *target, Err: err, At: c.now()})
+	}
+}
+
+// 通知を送る。受け取られないまま溜まっている場合は、監視を止めないよう古い通知から捨てる
+func (w *Watcher) emit(event WatchEvent) {
+	for {
+		select {
+		case w.events <- event:
+			return
+		default:
+		}
+
+		select {
+		case <-w.events:
+		default:
+		}
+	}
+}
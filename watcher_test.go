@@ -0,0 +1,230 @@
+package tcmrsv
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 練習室の行の他の人の予約をすべて空きにする
+func freeRoomRow(page, roomName string) string {
+	start := strings.Index(page, "<span>"+roomName+"</span>")
+	end := start + strings.Index(page[start:], "</tr>")
+	row := strings.ReplaceAll(page[start:end], `<td class="judgment2"></td>`, `<td class="judgment4">〇</td>`)
+	return page[:start] + row + page[end:]
+}
+
+func TestWatcher(t *testing.T) {
+	tomorrow := Today().AddDays(1)
+	a402 := "67f2e624-2f48-ec11-8c60-002248696fd6"
+
+	options := WatcherOptions{
+		Interval: time.Millisecond,
+		Backoff: RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+			Multiplier:     2,
+		},
+	}
+
+	// 2 回目の取得から A402 がキャンセルで空き、予約できる
+	freedRoutes := func(polls *atomic.Int32) map[string]http.HandlerFunc {
+		page := LoadFixture("personal/facility/reserve_without_inputs.html")
+		freed := freeRoomRow(page, "A402（G2台）")
+
+		return map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				if polls.Add(1) == 1 {
+					w.Write([]byte(page))
+					return
+				}
+				w.Write([]byte(freed))
+			},
+			"GET /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/confirms.html")))
+			},
+			"POST /personal/facility/confirms.aspx": func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(LoadFixture("personal/facility/done.html")))
+			},
+			"GET /personal/facility/index.aspx": func(w http.ResponseWriter, r *http.Request) {
+				list := strings.ReplaceAll(reservationListFor(tomorrow), "A415（G）", "A402（G2台）")
+				w.Write([]byte(strings.ReplaceAll(list, "17:00-22:30", "18:30-20:00")))
+			},
+		}
+	}
+
+	autoReserveA402 := WatchTarget{
+		Campus:      CampusIkebukuro,
+		Date:        tomorrow,
+		Rooms:       &GetRoomsFilteredParams{ID: &a402},
+		FromHour:    18,
+		FromMinute:  30,
+		ToHour:      20,
+		ToMinute:    0,
+		AutoReserve: true,
+	}
+
+	t.Run("NotifiesAndReservesFreedSlots", func(t *testing.T) {
+		var polls atomic.Int32
+		routes := freedRoutes(&polls)
+
+		mockServer := NewMockServer(CreateHandler(routes))
+		defer mockServer.Close()
+
+		options := options
+		options.Targets = []WatchTarget{autoReserveA402}
+
+		watcher, err := mockServer.Client.NewWatcher(options)
+		if err != nil {
+			t.Fatalf("Expected watcher to be created, got error: %v", err)
+		}
+		watcher.minInterval = time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- watcher.Run(ctx) }()
+
+		var events []WatchEvent
+		for event := range watcher.Events() {
+			events = append(events, event)
+		}
+
+		// 予約が完了すると監視する対象がなくなり終了する
+		if err := <-errCh; err != nil {
+			t.Fatalf("Expected watcher to finish, got error: %v", err)
+		}
+		if ctx.Err() != nil {
+			t.Fatal("Expected watcher to finish before timeout")
+		}
+
+		if len(events) != 2 {
+			t.Fatalf("Expected 2 events, got %d: %+v", len(events), events)
+		}
+
+		if events[0].Type != WatchEventSlotFreed || events[0].Room.ID != a402 {
+			t.Errorf("Expected A402 to be freed, got %+v", events[0])
+		}
+		// 監視している時間帯で空いたのは 18:30 から 20:00 まで
+		expected := []AvailableTime{{18, 30}, {19, 0}, {19, 30}}
+		if len(events[0].Times) != len(expected) {
+			t.Fatalf("Expected freed times %v, got %v", expected, events[0].Times)
+		}
+		for i, at := range expected {
+			if events[0].Times[i] != at {
+				t.Errorf("Expected freed times %v, got %v", expected, events[0].Times)
+			}
+		}
+
+		if events[1].Type != WatchEventReserved || events[1].Reservation == nil {
+			t.Fatalf("Expected reservation event, got %+v", events[1])
+		}
+		if events[1].Reservation.RoomName != "A402（G2台）" || events[1].Err != nil {
+			t.Errorf("Unexpected reservation event: %+v", events[1])
+		}
+
+		// 予約には取得済みの空き状況を使い、予約ページを取得し直さない
+		if polls.Load() != 2 {
+			t.Errorf("Expected reserve page to be fetched twice, got %d", polls.Load())
+		}
+	})
+
+	t.Run("ReservesWithoutReadingEvents", func(t *testing.T) {
+		var polls atomic.Int32
+		mockServer := NewMockServer(CreateHandler(freedRoutes(&polls)))
+		defer mockServer.Close()
+
+		options := options
+		options.Targets = []WatchTarget{autoReserveA402}
+
+		watcher, err := mockServer.Client.NewWatcher(options)
+		if err != nil {
+			t.Fatalf("Expected watcher to be created, got error: %v", err)
+		}
+		watcher.minInterval = time.Millisecond
+		// 通知が 1 件しか溜まらないようにする
+		watcher.events = make(chan WatchEvent, 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// 通知を受け取らなくても止まらずに予約まで進む
+		if err := watcher.Run(ctx); err != nil {
+			t.Fatalf("Expected watcher to finish, got error: %v", err)
+		}
+		if ctx.Err() != nil {
+			t.Fatal("Expected watcher to finish before timeout")
+		}
+
+		// 古い通知が捨てられ、最後の予約の通知が残る
+		var events []WatchEvent
+		for event := range watcher.Events() {
+			events = append(events, event)
+		}
+		if len(events) != 1 || events[0].Type != WatchEventReserved {
+			t.Errorf("Expected only the reservation event to remain, got %+v", events)
+		}
+
+		// 終了した Watcher は再開できない
+		if err := watcher.Run(ctx); err != ErrWatcherFinished {
+			t.Errorf("Expected watcher finished error, got: %v", err)
+		}
+	})
+
+	t.Run("BacksOffOnInternalServerError", func(t *testing.T) {
+		var polls atomic.Int32
+		routes := map[string]http.HandlerFunc{
+			"GET /personal/facility/reserve.aspx": func(w http.ResponseWriter, r *http.Request) {
+				polls.Add(1)
+				w.Write([]byte(LoadFixture("errorpage.html")))
+			},
+		}
+
+		mockServer := NewMockServer(CreateHandler(routes), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		defer mockServer.Close()
+
+		options := options
+		options.Targets = []WatchTarget{{Campus: CampusIkebukuro, Date: tomorrow}}
+
+		watcher, err := mockServer.Client.NewWatcher(options)
+		if err != nil {
+			t.Fatalf("Expected watcher to be created, got error: %v", err)
+		}
+		watcher.minInterval = time.Millisecond
+
+		go watcher.Run(context.Background())
+
+		event := <-watcher.Events()
+		if event.Type != WatchEventError || event.Err != ErrInternalServer {
+			t.Errorf("Expected internal server error event, got %+v", event)
+		}
+
+		// 通知を受け取らなくても止められる
+		watcher.Stop()
+
+		for range watcher.Events() {
+		}
+		if polls.Load() == 0 {
+			t.Error("Expected availability to be polled")
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		client := New()
+
+		_, err := client.NewWatcher(WatcherOptions{Targets: []WatchTarget{{Campus: Campus("invalid"), Date: tomorrow}}})
+		if err != ErrInvalidCampus {
+			t.Errorf("Expected invalid campus error, got: %v", err)
+		}
+
+		// 自動予約には時間帯が必要
+		_, err = client.NewWatcher(WatcherOptions{Targets: []WatchTarget{{Campus: CampusIkebukuro, Date: tomorrow, AutoReserve: true}}})
+		if err != ErrInvalidTimeRange {
+			t.Errorf("Expected invalid time range error, got: %v", err)
+		}
+	})
+}